@@ -1,10 +1,15 @@
 package main
 
 import (
+	"context"
+	"errors"
 	"fmt"
 	"log"
 	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
+	"time"
 
 	"k8_gui/internal/k8s"
 	"k8_gui/internal/server"
@@ -19,23 +24,40 @@ func main() {
 		log.Println(utils.LogNoEnvFile)
 	}
 
-	clientset, metricsClient, err := k8s.InitK8sClient()
+	clientset, metricsClient, restConfig, err := k8s.InitK8sClient()
 	if err != nil {
 		log.Printf(utils.LogWarnInitK8sClient, err)
 		log.Println(utils.LogLimitedServer)
 		clientset = nil
 		metricsClient = nil
+		restConfig = nil
 	} else {
 		log.Println(utils.LogK8sClientInitSuccess)
 	}
 
-	router := server.NewRouter(clientset, metricsClient)
+	// Cancelled on SIGINT/SIGTERM to stop background work and shut the server down
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	router := server.NewRouter(ctx, clientset, metricsClient, restConfig)
 
 	port := os.Getenv("PORT")
 	if port == "" {
 		port = "8081"
 	}
 
+	srv := &http.Server{Addr: ":" + port, Handler: router}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			log.Printf(utils.LogFailedShutdownServer, err)
+		}
+	}()
+
 	fmt.Printf("Starting server on port %s...\n", port)
-	log.Fatal(http.ListenAndServe(":"+port, router))
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		log.Fatal(err)
+	}
 }
@@ -0,0 +1,410 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ListConfigMaps returns all configmaps without their contents
+func ListConfigMaps(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		configMaps, err := clientset.CoreV1().ConfigMaps("").List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListConfigMaps, err)
+			http.Error(w, utils.MsgFailedListConfigMaps, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.ConfigMapListResponse{Items: make([]models.ConfigMap, 0, len(configMaps.Items))}
+		for _, c := range configMaps.Items {
+			response.Items = append(response.Items, toConfigMap(&c, false))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeConfigMapsList, err)
+		}
+	}
+}
+
+// GetConfigMap returns a configmap with its data, optionally listing the pods and workloads that use it
+func GetConfigMap(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		withConsumers := false
+		if v := r.URL.Query().Get("consumers"); v != "" {
+			parsed, err := strconv.ParseBool(v)
+			if err != nil {
+				http.Error(w, utils.MsgInvalidConsumersParam, http.StatusBadRequest)
+				return
+			}
+			withConsumers = parsed
+		}
+
+		configMap, err := clientset.CoreV1().ConfigMaps(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetConfigMap, err)
+			http.Error(w, utils.MsgConfigMapNotFound, http.StatusNotFound)
+			return
+		}
+
+		response := toConfigMap(configMap, true)
+		if withConsumers {
+			consumers, err := findConfigMapConsumers(r.Context(), clientset, namespace, name)
+			if err != nil {
+				log.Printf(utils.LogFailedFindConsumers, name, err)
+				http.Error(w, utils.MsgFailedFindConsumers, http.StatusInternalServerError)
+				return
+			}
+			response.Consumers = consumers
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeConfigMap, err)
+		}
+	}
+}
+
+// CreateConfigMap creates a new configmap
+func CreateConfigMap(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.CreateConfigMapRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		var errs field.ErrorList
+		errs = append(errs, validateObjectName(req.Name, field.NewPath("name"))...)
+		errs = append(errs, validateNamespace(req.Namespace, field.NewPath("namespace"))...)
+		errs = append(errs, validateLabels(req.Labels, field.NewPath("labels"))...)
+		errs = append(errs, validateDataKeys(req.Data, req.BinaryData)...)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		configMap := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      req.Name,
+				Namespace: req.Namespace,
+				Labels:    req.Labels,
+			},
+			Data:       req.Data,
+			BinaryData: req.BinaryData,
+		}
+		if req.Immutable {
+			immutable := true
+			configMap.Immutable = &immutable
+		}
+
+		created, err := clientset.CoreV1().ConfigMaps(req.Namespace).Create(r.Context(), configMap, metav1.CreateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedCreateConfigMap, err)
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			if apierrors.IsAlreadyExists(err) {
+				http.Error(w, utils.MsgConfigMapAlreadyExists, http.StatusConflict)
+				return
+			}
+			http.Error(w, utils.MsgFailedCreateConfigMap, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(toConfigMap(created, true)); err != nil {
+			log.Printf(utils.LogFailedEncodeCreatedConfigMap, err)
+		}
+	}
+}
+
+// UpdateConfigMap replaces the data of a configmap and optionally restarts the workloads using it
+func UpdateConfigMap(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		var req models.UpdateConfigMapRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		var errs field.ErrorList
+		errs = append(errs, validateLabels(req.Labels, field.NewPath("labels"))...)
+		errs = append(errs, validateDataKeys(req.Data, req.BinaryData)...)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		configMap, err := clientset.CoreV1().ConfigMaps(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetConfigMap, err)
+			http.Error(w, utils.MsgConfigMapNotFound, http.StatusNotFound)
+			return
+		}
+		if req.ResourceVersion != "" && req.ResourceVersion != configMap.ResourceVersion {
+			http.Error(w, utils.MsgConfigMapModified, http.StatusConflict)
+			return
+		}
+
+		configMap.Data = req.Data
+		configMap.BinaryData = req.BinaryData
+		if req.Labels != nil {
+			configMap.Labels = req.Labels
+		}
+
+		// The resourceVersion from the read above makes the API server reject concurrent writes
+		updated, err := clientset.CoreV1().ConfigMaps(namespace).Update(r.Context(), configMap, metav1.UpdateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedUpdateConfigMap, name, err)
+			if apierrors.IsConflict(err) {
+				http.Error(w, utils.MsgConfigMapModified, http.StatusConflict)
+				return
+			}
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			http.Error(w, utils.MsgFailedUpdateConfigMap, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.UpdateConfigMapResponse{ConfigMap: toConfigMap(updated, true)}
+		if req.RestartConsumers {
+			consumers, err := findConfigMapConsumers(r.Context(), clientset, namespace, name)
+			if err != nil {
+				log.Printf(utils.LogFailedFindConsumers, name, err)
+				response.RestartErrors = append(response.RestartErrors, utils.MsgFailedFindConsumers)
+			}
+			response.Restarted, response.RestartErrors = restartConsumers(r.Context(), clientset, consumers, response.RestartErrors)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeUpdatedConfigMap, err)
+		}
+	}
+}
+
+// DeleteConfigMap deletes a configmap
+func DeleteConfigMap(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		err := clientset.CoreV1().ConfigMaps(namespace).Delete(r.Context(), name, metav1.DeleteOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedDeleteConfigMap, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgConfigMapNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedDeleteConfigMap, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// validateDataKeys checks configmap or secret key syntax and that no key appears in both data and binaryData
+func validateDataKeys(data map[string]string, binaryData map[string][]byte) field.ErrorList {
+	var errs field.ErrorList
+	for k := range data {
+		for _, msg := range validation.IsConfigMapKey(k) {
+			errs = append(errs, field.Invalid(field.NewPath("data").Key(k), k, msg))
+		}
+	}
+	for k := range binaryData {
+		for _, msg := range validation.IsConfigMapKey(k) {
+			errs = append(errs, field.Invalid(field.NewPath("binaryData").Key(k), k, msg))
+		}
+		if _, ok := data[k]; ok {
+			errs = append(errs, field.Duplicate(field.NewPath("binaryData").Key(k), k))
+		}
+	}
+	return errs
+}
+
+// findConfigMapConsumers lists the pods and workloads in a namespace that reference a configmap
+func findConfigMapConsumers(ctx context.Context, clientset *kubernetes.Clientset, namespace, name string) ([]models.ConfigMapConsumer, error) {
+	consumers := []models.ConfigMapConsumer{}
+	add := func(kind, objName string, spec *corev1.PodSpec, paused bool) {
+		if refs := podSpecConfigMapReferences(spec, name); len(refs) > 0 {
+			consumers = append(consumers, models.ConfigMapConsumer{
+				Kind:       kind,
+				Namespace:  namespace,
+				Name:       objName,
+				Paused:     paused,
+				References: refs,
+			})
+		}
+	}
+
+	deployments, err := clientset.AppsV1().Deployments(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range deployments.Items {
+		add("Deployment", deployments.Items[i].Name, &deployments.Items[i].Spec.Template.Spec, deployments.Items[i].Spec.Paused)
+	}
+
+	statefulSets, err := clientset.AppsV1().StatefulSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range statefulSets.Items {
+		add("StatefulSet", statefulSets.Items[i].Name, &statefulSets.Items[i].Spec.Template.Spec, false)
+	}
+
+	daemonSets, err := clientset.AppsV1().DaemonSets(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range daemonSets.Items {
+		add("DaemonSet", daemonSets.Items[i].Name, &daemonSets.Items[i].Spec.Template.Spec, false)
+	}
+
+	pods, err := clientset.CoreV1().Pods(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	for i := range pods.Items {
+		add("Pod", pods.Items[i].Name, &pods.Items[i].Spec, false)
+	}
+
+	return consumers, nil
+}
+
+// podSpecConfigMapReferences describes every env, envFrom and volume reference to a configmap
+func podSpecConfigMapReferences(spec *corev1.PodSpec, name string) []string {
+	var refs []string
+
+	containers := append(append([]corev1.Container{}, spec.InitContainers...), spec.Containers...)
+	for _, c := range containers {
+		for _, e := range c.Env {
+			if e.ValueFrom != nil && e.ValueFrom.ConfigMapKeyRef != nil && e.ValueFrom.ConfigMapKeyRef.Name == name {
+				refs = append(refs, fmt.Sprintf("env:%s/%s", c.Name, e.Name))
+			}
+		}
+		for _, e := range c.EnvFrom {
+			if e.ConfigMapRef != nil && e.ConfigMapRef.Name == name {
+				refs = append(refs, "envFrom:"+c.Name)
+			}
+		}
+	}
+
+	for _, v := range spec.Volumes {
+		if v.ConfigMap != nil && v.ConfigMap.Name == name {
+			refs = append(refs, "volume:"+v.Name)
+		}
+		if v.Projected != nil {
+			for _, s := range v.Projected.Sources {
+				if s.ConfigMap != nil && s.ConfigMap.Name == name {
+					refs = append(refs, "volume:"+v.Name)
+				}
+			}
+		}
+	}
+	return refs
+}
+
+// restartConsumers rolls every workload consumer the same way kubectl rollout restart does.
+// Bare pods are skipped since they cannot be restarted in place, and paused deployments are
+// reported as not restarted because the patch would not roll them out.
+func restartConsumers(ctx context.Context, clientset *kubernetes.Clientset, consumers []models.ConfigMapConsumer, errs []string) ([]models.ConfigMapConsumer, []string) {
+	data, err := json.Marshal(map[string]interface{}{
+		"spec": map[string]interface{}{
+			"template": map[string]interface{}{
+				"metadata": map[string]interface{}{
+					"annotations": map[string]string{
+						restartedAtAnnotation: time.Now().Format(time.RFC3339),
+					},
+				},
+			},
+		},
+	})
+	if err != nil {
+		return nil, append(errs, err.Error())
+	}
+
+	restarted := []models.ConfigMapConsumer{}
+	for _, c := range consumers {
+		switch c.Kind {
+		case "Deployment":
+			if c.Paused {
+				errs = append(errs, fmt.Sprintf("%s %s/%s: %s", c.Kind, c.Namespace, c.Name, utils.MsgDeploymentPaused))
+				continue
+			}
+			_, err = clientset.AppsV1().Deployments(c.Namespace).Patch(ctx, c.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+		case "StatefulSet":
+			_, err = clientset.AppsV1().StatefulSets(c.Namespace).Patch(ctx, c.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+		case "DaemonSet":
+			_, err = clientset.AppsV1().DaemonSets(c.Namespace).Patch(ctx, c.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+		default:
+			continue
+		}
+		if err != nil {
+			log.Printf(utils.LogFailedRestartConsumer, c.Kind, c.Namespace, c.Name, err)
+			errs = append(errs, fmt.Sprintf("%s %s/%s: %v", c.Kind, c.Namespace, c.Name, err))
+			continue
+		}
+		restarted = append(restarted, c)
+	}
+	return restarted, errs
+}
+
+// toConfigMap converts a configmap into the simplified view, with its contents when withData is set
+func toConfigMap(c *corev1.ConfigMap, withData bool) models.ConfigMap {
+	keys := make([]string, 0, len(c.Data)+len(c.BinaryData))
+	for k := range c.Data {
+		keys = append(keys, k)
+	}
+	for k := range c.BinaryData {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	configMap := models.ConfigMap{
+		Name:            c.Name,
+		Namespace:       c.Namespace,
+		Keys:            keys,
+		Immutable:       c.Immutable != nil && *c.Immutable,
+		ResourceVersion: c.ResourceVersion,
+		CreatedAt:       c.CreationTimestamp.Time.Format(time.RFC3339),
+		Labels:          c.Labels,
+	}
+	if withData {
+		configMap.Data = c.Data
+		configMap.BinaryData = c.BinaryData
+	}
+	return configMap
+}
@@ -0,0 +1,372 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/gorilla/mux"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/selection"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+)
+
+// daemonTolerations are added to every daemon pod by the DaemonSet controller
+var daemonTolerations = []corev1.Toleration{
+	{Key: corev1.TaintNodeNotReady, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeUnreachable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoExecute},
+	{Key: corev1.TaintNodeDiskPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeMemoryPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodePIDPressure, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+	{Key: corev1.TaintNodeUnschedulable, Operator: corev1.TolerationOpExists, Effect: corev1.TaintEffectNoSchedule},
+}
+
+// ListDaemonSets returns all daemonsets
+func ListDaemonSets(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		daemonSets, err := clientset.AppsV1().DaemonSets("").List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListDaemonSets, err)
+			http.Error(w, utils.MsgFailedListDaemonSets, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.DaemonSetListResponse{Items: make([]models.DaemonSet, 0, len(daemonSets.Items))}
+		for _, d := range daemonSets.Items {
+			response.Items = append(response.Items, toDaemonSet(&d))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeDaemonSetsList, err)
+		}
+	}
+}
+
+// GetDaemonSet returns daemonset details
+func GetDaemonSet(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		daemonSet, err := clientset.AppsV1().DaemonSets(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetDaemonSet, err)
+			http.Error(w, utils.MsgDaemonSetNotFound, http.StatusNotFound)
+			return
+		}
+
+		response := toDaemonSet(daemonSet)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeDaemonSet, err)
+		}
+	}
+}
+
+// GetDaemonSetNodes reports, for every node, whether it should run a daemon pod and whether it does
+func GetDaemonSetNodes(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		daemonSet, err := clientset.AppsV1().DaemonSets(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetDaemonSet, err)
+			http.Error(w, utils.MsgDaemonSetNotFound, http.StatusNotFound)
+			return
+		}
+
+		nodes, err := clientset.CoreV1().Nodes().List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListNodes, err)
+			http.Error(w, utils.MsgFailedGetDaemonSetNodes, http.StatusInternalServerError)
+			return
+		}
+
+		selector, err := metav1.LabelSelectorAsSelector(daemonSet.Spec.Selector)
+		if err != nil {
+			log.Printf(utils.LogFailedListDaemonSetPods, name, err)
+			http.Error(w, utils.MsgFailedGetDaemonSetNodes, http.StatusInternalServerError)
+			return
+		}
+		pods, err := clientset.CoreV1().Pods(namespace).List(r.Context(), metav1.ListOptions{LabelSelector: selector.String()})
+		if err != nil {
+			log.Printf(utils.LogFailedListDaemonSetPods, name, err)
+			http.Error(w, utils.MsgFailedGetDaemonSetNodes, http.StatusInternalServerError)
+			return
+		}
+
+		response := daemonSetNodes(daemonSet, nodes.Items, pods.Items)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeDaemonSetNodes, err)
+		}
+	}
+}
+
+// DeleteDaemonSet deletes a daemonset
+func DeleteDaemonSet(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		opts, err := parseDeleteOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = clientset.AppsV1().DaemonSets(namespace).Delete(r.Context(), name, opts)
+		if err != nil {
+			log.Printf(utils.LogFailedDeleteDaemonSet, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgDaemonSetNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedDeleteDaemonSet, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RestartDaemonSet triggers a rolling restart the same way kubectl rollout restart does
+func RestartDaemonSet(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		patch := map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]string{
+							restartedAtAnnotation: time.Now().Format(time.RFC3339),
+						},
+					},
+				},
+			},
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			log.Printf(utils.LogFailedPatchDaemonSet, name, err)
+			http.Error(w, utils.MsgFailedUpdateDaemonSet, http.StatusInternalServerError)
+			return
+		}
+
+		patched, err := clientset.AppsV1().DaemonSets(namespace).Patch(r.Context(), name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedPatchDaemonSet, name, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgDaemonSetNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedUpdateDaemonSet, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toDaemonSet(patched)); err != nil {
+			log.Printf(utils.LogFailedEncodeUpdatedDaemonSet, err)
+		}
+	}
+}
+
+// daemonSetNodes joins nodes with the daemon pods running on them
+func daemonSetNodes(ds *appsv1.DaemonSet, nodes []corev1.Node, pods []corev1.Pod) models.DaemonSetNodesResponse {
+	podsByNode := make(map[string]*corev1.Pod, len(pods))
+	for i := range pods {
+		pod := &pods[i]
+		if !metav1.IsControlledBy(pod, ds) {
+			continue
+		}
+		if nodeName := daemonPodNode(pod); nodeName != "" {
+			podsByNode[nodeName] = pod
+		}
+	}
+
+	sort.Slice(nodes, func(i, j int) bool { return nodes[i].Name < nodes[j].Name })
+
+	response := models.DaemonSetNodesResponse{
+		Items:        make([]models.DaemonSetNode, 0, len(nodes)),
+		Missing:      []string{},
+		Misscheduled: []string{},
+	}
+	for i := range nodes {
+		node := &nodes[i]
+		entry := models.DaemonSetNode{NodeName: node.Name}
+		scheduleReason, runReason := daemonSetIneligibleReason(&ds.Spec.Template.Spec, node)
+		entry.Reason = scheduleReason
+		entry.Eligible = scheduleReason == ""
+
+		if pod, ok := podsByNode[node.Name]; ok {
+			entry.Pod = pod.Name
+			entry.Ready = podConditionTrue(pod, corev1.PodReady)
+			entry.Status, _ = podDisplayStatus(pod)
+		}
+
+		entry.Missing = entry.Eligible && entry.Pod == ""
+		// Like the controller, only evict-worthy mismatches make a running pod misscheduled
+		entry.Misscheduled = runReason != "" && entry.Pod != ""
+		if entry.Missing {
+			response.Missing = append(response.Missing, node.Name)
+		}
+		if entry.Misscheduled {
+			response.Misscheduled = append(response.Misscheduled, node.Name)
+		}
+		response.Items = append(response.Items, entry)
+	}
+	return response
+}
+
+// daemonPodNode returns the node a daemon pod is bound to, or targeted at while still pending
+func daemonPodNode(pod *corev1.Pod) string {
+	if pod.Spec.NodeName != "" {
+		return pod.Spec.NodeName
+	}
+	// The controller pins unscheduled daemon pods with a metadata.name node affinity
+	if pod.Spec.Affinity == nil || pod.Spec.Affinity.NodeAffinity == nil ||
+		pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		return ""
+	}
+	for _, term := range pod.Spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution.NodeSelectorTerms {
+		for _, f := range term.MatchFields {
+			if f.Key == "metadata.name" && f.Operator == corev1.NodeSelectorOpIn && len(f.Values) == 1 {
+				return f.Values[0]
+			}
+		}
+	}
+	return ""
+}
+
+// daemonSetIneligibleReason explains why a new daemon pod should not be scheduled on a node and
+// why an existing one should not keep running there; each is "" when it should. An untolerated
+// NoSchedule taint only blocks scheduling, while selector, affinity and NoExecute mismatches block both.
+func daemonSetIneligibleReason(spec *corev1.PodSpec, node *corev1.Node) (schedule, run string) {
+	if len(spec.NodeSelector) > 0 && !labels.SelectorFromSet(spec.NodeSelector).Matches(labels.Set(node.Labels)) {
+		return "node does not match nodeSelector", "node does not match nodeSelector"
+	}
+
+	if spec.Affinity != nil && spec.Affinity.NodeAffinity != nil {
+		if required := spec.Affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution; required != nil {
+			matched := false
+			for _, term := range required.NodeSelectorTerms {
+				if nodeSelectorTermMatches(term, node) {
+					matched = true
+					break
+				}
+			}
+			if !matched {
+				return "node does not match required node affinity", "node does not match required node affinity"
+			}
+		}
+	}
+
+	tolerations := append(append([]corev1.Toleration{}, spec.Tolerations...), daemonTolerations...)
+	if spec.HostNetwork {
+		tolerations = append(tolerations, corev1.Toleration{
+			Key:      corev1.TaintNodeNetworkUnavailable,
+			Operator: corev1.TolerationOpExists,
+			Effect:   corev1.TaintEffectNoSchedule,
+		})
+	}
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+		tolerated := false
+		for j := range tolerations {
+			if tolerations[j].ToleratesTaint(taint) {
+				tolerated = true
+				break
+			}
+		}
+		if !tolerated {
+			reason := fmt.Sprintf("untolerated taint %s", taint.ToString())
+			if taint.Effect == corev1.TaintEffectNoExecute {
+				return reason, reason
+			}
+			if schedule == "" {
+				schedule = reason
+			}
+		}
+	}
+	return schedule, ""
+}
+
+// nodeSelectorTermMatches evaluates a node selector term; an empty term matches nothing
+func nodeSelectorTermMatches(term corev1.NodeSelectorTerm, node *corev1.Node) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+	if !nodeSelectorRequirementsMatch(term.MatchExpressions, labels.Set(node.Labels)) {
+		return false
+	}
+	return nodeSelectorRequirementsMatch(term.MatchFields, labels.Set{"metadata.name": node.Name})
+}
+
+func nodeSelectorRequirementsMatch(requirements []corev1.NodeSelectorRequirement, set labels.Set) bool {
+	operators := map[corev1.NodeSelectorOperator]selection.Operator{
+		corev1.NodeSelectorOpIn:           selection.In,
+		corev1.NodeSelectorOpNotIn:        selection.NotIn,
+		corev1.NodeSelectorOpExists:       selection.Exists,
+		corev1.NodeSelectorOpDoesNotExist: selection.DoesNotExist,
+		corev1.NodeSelectorOpGt:           selection.GreaterThan,
+		corev1.NodeSelectorOpLt:           selection.LessThan,
+	}
+	for _, req := range requirements {
+		op, ok := operators[req.Operator]
+		if !ok {
+			return false
+		}
+		requirement, err := labels.NewRequirement(req.Key, op, req.Values)
+		if err != nil || !requirement.Matches(set) {
+			return false
+		}
+	}
+	return true
+}
+
+// toDaemonSet converts a daemonset into the simplified view
+func toDaemonSet(d *appsv1.DaemonSet) models.DaemonSet {
+	images := make([]string, 0, len(d.Spec.Template.Spec.Containers))
+	for _, c := range d.Spec.Template.Spec.Containers {
+		images = append(images, c.Image)
+	}
+
+	return models.DaemonSet{
+		Name:                   d.Name,
+		Namespace:              d.Namespace,
+		DesiredNumberScheduled: d.Status.DesiredNumberScheduled,
+		CurrentNumberScheduled: d.Status.CurrentNumberScheduled,
+		NumberReady:            d.Status.NumberReady,
+		UpdatedNumberScheduled: d.Status.UpdatedNumberScheduled,
+		NumberAvailable:        d.Status.NumberAvailable,
+		NumberMisscheduled:     d.Status.NumberMisscheduled,
+		NodeSelector:           d.Spec.Template.Spec.NodeSelector,
+		UpdateStrategy:         string(d.Spec.UpdateStrategy.Type),
+		Images:                 images,
+		CreatedAt:              d.CreationTimestamp.Time.Format(time.RFC3339),
+		RestartedAt:            d.Spec.Template.Annotations[restartedAtAnnotation],
+		Labels:                 d.Labels,
+	}
+}
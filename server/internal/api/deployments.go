@@ -10,11 +10,18 @@ import (
 
 	"github.com/gorilla/mux"
 	appsv1 "k8s.io/api/apps/v1"
-	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
 )
 
+// restartedAtAnnotation is the pod template annotation kubectl rollout restart bumps
+const restartedAtAnnotation = "kubectl.kubernetes.io/restartedAt"
+
 // ListDeployments returns all deployments
 func ListDeployments(clientset *kubernetes.Clientset) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -27,15 +34,7 @@ func ListDeployments(clientset *kubernetes.Clientset) http.HandlerFunc {
 
 		response := models.DeploymentListResponse{Items: make([]models.Deployment, 0, len(deployments.Items))}
 		for _, d := range deployments.Items {
-			response.Items = append(response.Items, models.Deployment{
-				Name:              d.Name,
-				Namespace:         d.Namespace,
-				Replicas:          *d.Spec.Replicas,
-				AvailableReplicas: d.Status.AvailableReplicas,
-				CreatedAt:         d.CreationTimestamp.Time.Format(time.RFC3339),
-				Strategy:          string(d.Spec.Strategy.Type),
-				Labels:            d.Labels,
-			})
+			response.Items = append(response.Items, toDeployment(&d))
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -59,15 +58,7 @@ func GetDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
-		response := models.Deployment{
-			Name:              deployment.Name,
-			Namespace:         deployment.Namespace,
-			Replicas:          *deployment.Spec.Replicas,
-			AvailableReplicas: deployment.Status.AvailableReplicas,
-			CreatedAt:         deployment.CreationTimestamp.Time.Format(time.RFC3339),
-			Strategy:          string(deployment.Spec.Strategy.Type),
-			Labels:            deployment.Labels,
-		}
+		response := toDeployment(deployment)
 
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -85,44 +76,23 @@ func CreateDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
-		deployment := &appsv1.Deployment{
-			ObjectMeta: metav1.ObjectMeta{
-				Name:      req.Name,
-				Namespace: req.Namespace,
-			},
-			Spec: appsv1.DeploymentSpec{
-				Replicas: &req.Replicas,
-				Selector: &metav1.LabelSelector{
-					MatchLabels: map[string]string{
-						"app": req.Name,
-					},
-				},
-				Template: corev1.PodTemplateSpec{
-					ObjectMeta: metav1.ObjectMeta{
-						Labels: map[string]string{
-							"app": req.Name,
-						},
-					},
-					Spec: corev1.PodSpec{
-						Containers: []corev1.Container{
-							{
-								Name:  req.Name,
-								Image: req.Image,
-								Ports: []corev1.ContainerPort{
-									{
-										ContainerPort: req.Port,
-									},
-								},
-							},
-						},
-					},
-				},
-			},
+		deployment, errs := buildDeployment(req)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
 		}
 
 		created, err := clientset.AppsV1().Deployments(req.Namespace).Create(r.Context(), deployment, metav1.CreateOptions{})
 		if err != nil {
 			log.Printf(utils.LogFailedCreateDeployment, err)
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			if apierrors.IsAlreadyExists(err) {
+				http.Error(w, utils.MsgDeploymentAlreadyExists, http.StatusConflict)
+				return
+			}
 			http.Error(w, utils.MsgFailedCreateDeployment, http.StatusInternalServerError)
 			return
 		}
@@ -135,7 +105,7 @@ func CreateDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
 	}
 }
 
-// UpdateDeployment updates an existing deployment
+// UpdateDeployment updates the image of the first container and/or the replica count
 func UpdateDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
@@ -155,28 +125,184 @@ func UpdateDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
-		// Update image and replicas
+		patch := models.PatchDeploymentRequest{
+			ResourceVersion: deployment.ResourceVersion,
+			Replicas:        req.Replicas,
+		}
 		if req.Image != "" {
-			deployment.Spec.Template.Spec.Containers[0].Image = req.Image
+			containers := deployment.Spec.Template.Spec.Containers
+			if len(containers) == 0 {
+				writeValidationErrors(w, fieldErrors(field.ErrorList{
+					field.Invalid(field.NewPath("image"), req.Image, "deployment has no containers"),
+				}))
+				return
+			}
+			patch.Containers = []models.ContainerPatch{{Name: containers[0].Name, Image: req.Image}}
 		}
-		if req.Replicas > 0 {
-			deployment.Spec.Replicas = &req.Replicas
+
+		updated := applyDeploymentPatch(w, r, clientset, deployment, patch)
+		if updated == nil {
+			return
 		}
 
-		updated, err := clientset.AppsV1().Deployments(namespace).Update(r.Context(), deployment, metav1.UpdateOptions{})
+		// PUT keeps returning the full Deployment object; PATCH returns the simplified view
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(updated); err != nil {
+			log.Printf(utils.LogFailedEncodeUpdatedDeployment, err)
+		}
+	}
+}
+
+// PatchDeployment applies a partial update addressed by container name using a strategic merge patch
+func PatchDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		var req models.PatchDeploymentRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
 		if err != nil {
-			log.Printf(utils.LogFailedUpdateDeployment, err)
-			http.Error(w, utils.MsgFailedUpdateDeployment, http.StatusInternalServerError)
+			log.Printf(utils.LogFailedGetDeployment, err)
+			http.Error(w, utils.MsgDeploymentNotFound, http.StatusNotFound)
+			return
+		}
+		if req.ResourceVersion != "" && req.ResourceVersion != deployment.ResourceVersion {
+			http.Error(w, utils.MsgDeploymentModified, http.StatusConflict)
+			return
+		}
+		if req.ResourceVersion == "" {
+			// Still guard the window between validating against this read and patching
+			req.ResourceVersion = deployment.ResourceVersion
+		}
+
+		patched := applyDeploymentPatch(w, r, clientset, deployment, req)
+		if patched == nil {
 			return
 		}
 
 		w.Header().Set("Content-Type", "application/json")
-		if err := json.NewEncoder(w).Encode(updated); err != nil {
+		if err := json.NewEncoder(w).Encode(toDeployment(patched)); err != nil {
 			log.Printf(utils.LogFailedEncodeUpdatedDeployment, err)
 		}
 	}
 }
 
+// applyDeploymentPatch validates a patch request against the current deployment and applies it.
+// It returns the patched deployment, or nil after writing an error response.
+func applyDeploymentPatch(w http.ResponseWriter, r *http.Request, clientset *kubernetes.Clientset, deployment *appsv1.Deployment, req models.PatchDeploymentRequest) *appsv1.Deployment {
+	patch, errs := buildDeploymentPatch(deployment, req)
+	if len(errs) > 0 {
+		writeValidationErrors(w, fieldErrors(errs))
+		return nil
+	}
+
+	data, err := json.Marshal(patch)
+	if err != nil {
+		log.Printf(utils.LogFailedPatchDeployment, deployment.Name, err)
+		http.Error(w, utils.MsgFailedUpdateDeployment, http.StatusInternalServerError)
+		return nil
+	}
+
+	patched, err := clientset.AppsV1().Deployments(deployment.Namespace).Patch(r.Context(), deployment.Name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.Printf(utils.LogFailedPatchDeployment, deployment.Name, err)
+		switch {
+		case apierrors.IsConflict(err):
+			http.Error(w, utils.MsgDeploymentModified, http.StatusConflict)
+		case apierrors.IsNotFound(err):
+			http.Error(w, utils.MsgDeploymentNotFound, http.StatusNotFound)
+		default:
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return nil
+			}
+			http.Error(w, utils.MsgFailedUpdateDeployment, http.StatusInternalServerError)
+		}
+		return nil
+	}
+
+	return patched
+}
+
+// buildDeploymentPatch turns a patch request into a strategic merge patch keyed by container name.
+// The resourceVersion in the patch makes the API server enforce optimistic concurrency.
+func buildDeploymentPatch(deployment *appsv1.Deployment, req models.PatchDeploymentRequest) (map[string]interface{}, field.ErrorList) {
+	var errs field.ErrorList
+
+	spec := map[string]interface{}{}
+	patch := map[string]interface{}{
+		"metadata": map[string]interface{}{"resourceVersion": req.ResourceVersion},
+		"spec":     spec,
+	}
+
+	if req.Replicas != nil {
+		if *req.Replicas < 0 {
+			errs = append(errs, field.Invalid(field.NewPath("replicas"), *req.Replicas, "must be greater than or equal to 0"))
+		}
+		spec["replicas"] = *req.Replicas
+	}
+
+	existing := make(map[string]bool, len(deployment.Spec.Template.Spec.Containers))
+	for _, c := range deployment.Spec.Template.Spec.Containers {
+		existing[c.Name] = true
+	}
+
+	containers := make([]map[string]interface{}, 0, len(req.Containers))
+	for i, c := range req.Containers {
+		idxPath := field.NewPath("containers").Index(i)
+		if !existing[c.Name] {
+			errs = append(errs, field.NotFound(idxPath.Child("name"), c.Name))
+			continue
+		}
+
+		container := map[string]interface{}{"name": c.Name}
+		if c.Image != "" {
+			container["image"] = c.Image
+		}
+
+		env, envErrs := buildEnv(c.Env, idxPath.Child("env"))
+		errs = append(errs, envErrs...)
+		envPatch := make([]map[string]interface{}, 0, len(env)+len(c.RemoveEnv))
+		for _, e := range env {
+			// Null out the other variant so switching between literal and reference values merges cleanly
+			entry := map[string]interface{}{"name": e.Name, "value": nil, "valueFrom": nil}
+			if e.ValueFrom != nil {
+				entry["valueFrom"] = e.ValueFrom
+			} else {
+				entry["value"] = e.Value
+			}
+			envPatch = append(envPatch, entry)
+		}
+		for _, n := range c.RemoveEnv {
+			envPatch = append(envPatch, map[string]interface{}{"name": n, "$patch": "delete"})
+		}
+		if len(envPatch) > 0 {
+			container["env"] = envPatch
+		}
+
+		if c.Resources != nil {
+			resources, rErrs := buildResources(*c.Resources, idxPath.Child("resources"))
+			errs = append(errs, rErrs...)
+			container["resources"] = resources
+		}
+
+		containers = append(containers, container)
+	}
+	if len(containers) > 0 {
+		spec["template"] = map[string]interface{}{
+			"spec": map[string]interface{}{"containers": containers},
+		}
+	}
+
+	return patch, errs
+}
+
 // DeleteDeployment deletes a deployment
 func DeleteDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -194,3 +320,213 @@ func DeleteDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+// RestartDeployment triggers a rolling restart the same way kubectl rollout restart does
+func RestartDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetDeployment, err)
+			http.Error(w, utils.MsgDeploymentNotFound, http.StatusNotFound)
+			return
+		}
+		if deployment.Spec.Paused {
+			http.Error(w, utils.MsgDeploymentPaused, http.StatusConflict)
+			return
+		}
+
+		patch := map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]string{
+							restartedAtAnnotation: time.Now().Format(time.RFC3339),
+						},
+					},
+				},
+			},
+		}
+		patchDeployment(w, r, clientset, namespace, name, patch)
+	}
+}
+
+// PauseDeployment pauses the rollout of a deployment
+func PauseDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return setDeploymentPaused(clientset, true)
+}
+
+// ResumeDeployment resumes a paused deployment rollout
+func ResumeDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return setDeploymentPaused(clientset, false)
+}
+
+func setDeploymentPaused(clientset *kubernetes.Clientset, paused bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		patch := map[string]interface{}{
+			"spec": map[string]interface{}{
+				"paused": paused,
+			},
+		}
+		patchDeployment(w, r, clientset, vars["namespace"], vars["name"], patch)
+	}
+}
+
+// patchDeployment applies a strategic merge patch and writes the resulting deployment
+func patchDeployment(w http.ResponseWriter, r *http.Request, clientset *kubernetes.Clientset, namespace, name string, patch interface{}) {
+	data, err := json.Marshal(patch)
+	if err != nil {
+		log.Printf(utils.LogFailedPatchDeployment, name, err)
+		http.Error(w, utils.MsgFailedUpdateDeployment, http.StatusInternalServerError)
+		return
+	}
+
+	patched, err := clientset.AppsV1().Deployments(namespace).Patch(r.Context(), name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+	if err != nil {
+		log.Printf(utils.LogFailedPatchDeployment, name, err)
+		if apierrors.IsNotFound(err) {
+			http.Error(w, utils.MsgDeploymentNotFound, http.StatusNotFound)
+			return
+		}
+		http.Error(w, utils.MsgFailedUpdateDeployment, http.StatusInternalServerError)
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toDeployment(patched)); err != nil {
+		log.Printf(utils.LogFailedEncodeUpdatedDeployment, err)
+	}
+}
+
+// buildDeployment converts and validates a create request into a deployment object
+func buildDeployment(req models.CreateDeploymentRequest) (*appsv1.Deployment, field.ErrorList) {
+	var errs field.ErrorList
+
+	errs = append(errs, validateObjectName(req.Name, field.NewPath("name"))...)
+	errs = append(errs, validateNamespace(req.Namespace, field.NewPath("namespace"))...)
+	if req.Replicas < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("replicas"), req.Replicas, "must be greater than or equal to 0"))
+	}
+
+	labels := req.Labels
+	if len(labels) == 0 {
+		labels = map[string]string{"app": req.Name}
+	}
+	selector := req.Selector
+	if len(selector) == 0 {
+		selector = labels
+	}
+	errs = append(errs, validateLabels(labels, field.NewPath("labels"))...)
+	errs = append(errs, validateLabels(selector, field.NewPath("selector"))...)
+	for k, v := range selector {
+		if labels[k] != v {
+			errs = append(errs, field.Invalid(field.NewPath("selector").Key(k), v, "selector must match the pod template labels"))
+		}
+	}
+
+	opts := req.PodTemplateOptions
+	if len(opts.Containers) == 0 && req.Image != "" {
+		container := models.ContainerSpec{Name: req.Name, Image: req.Image}
+		if req.Port > 0 {
+			container.Ports = []models.ContainerPort{{ContainerPort: req.Port}}
+		}
+		opts.Containers = []models.ContainerSpec{container}
+	}
+
+	template, tErrs := buildPodTemplate(labels, nil, opts, nil, nil)
+	errs = append(errs, tErrs...)
+
+	replicas := req.Replicas
+	deployment := &appsv1.Deployment{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        req.Name,
+			Namespace:   req.Namespace,
+			Labels:      labels,
+			Annotations: req.Annotations,
+		},
+		Spec: appsv1.DeploymentSpec{
+			Replicas:                &replicas,
+			Selector:                &metav1.LabelSelector{MatchLabels: selector},
+			Template:                template,
+			MinReadySeconds:         req.MinReadySeconds,
+			RevisionHistoryLimit:    req.RevisionHistoryLimit,
+			ProgressDeadlineSeconds: req.ProgressDeadlineSeconds,
+		},
+	}
+
+	if req.Strategy != nil {
+		strategy, sErrs := buildDeploymentStrategy(*req.Strategy, field.NewPath("strategy"))
+		errs = append(errs, sErrs...)
+		deployment.Spec.Strategy = strategy
+	}
+
+	return deployment, errs
+}
+
+func buildDeploymentStrategy(spec models.DeploymentStrategy, fldPath *field.Path) (appsv1.DeploymentStrategy, field.ErrorList) {
+	var errs field.ErrorList
+	strategy := appsv1.DeploymentStrategy{Type: appsv1.DeploymentStrategyType(spec.Type)}
+
+	switch strategy.Type {
+	case appsv1.RecreateDeploymentStrategyType:
+		if spec.MaxSurge != "" || spec.MaxUnavailable != "" {
+			errs = append(errs, field.Forbidden(fldPath, "maxSurge and maxUnavailable are only allowed with RollingUpdate"))
+		}
+	case "", appsv1.RollingUpdateDeploymentStrategyType:
+		strategy.Type = appsv1.RollingUpdateDeploymentStrategyType
+		rolling := &appsv1.RollingUpdateDeployment{}
+		if spec.MaxSurge != "" {
+			v, vErrs := parseIntOrPercent(spec.MaxSurge, fldPath.Child("maxSurge"))
+			errs = append(errs, vErrs...)
+			rolling.MaxSurge = &v
+		}
+		if spec.MaxUnavailable != "" {
+			v, vErrs := parseIntOrPercent(spec.MaxUnavailable, fldPath.Child("maxUnavailable"))
+			errs = append(errs, vErrs...)
+			rolling.MaxUnavailable = &v
+		}
+		strategy.RollingUpdate = rolling
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("type"), spec.Type, []string{"RollingUpdate", "Recreate"}))
+	}
+	return strategy, errs
+}
+
+// parseIntOrPercent parses a non-negative integer or a percentage such as "25%"
+func parseIntOrPercent(value string, fldPath *field.Path) (intstr.IntOrString, field.ErrorList) {
+	v := intstr.Parse(value)
+	if v.Type == intstr.String {
+		for _, msg := range validation.IsValidPercent(value) {
+			return v, field.ErrorList{field.Invalid(fldPath, value, msg)}
+		}
+		return v, nil
+	}
+	if v.IntValue() < 0 {
+		return v, field.ErrorList{field.Invalid(fldPath, value, "must be greater than or equal to 0")}
+	}
+	return v, nil
+}
+
+// toDeployment converts a deployment into the simplified view
+func toDeployment(d *appsv1.Deployment) models.Deployment {
+	replicas := int32(1)
+	if d.Spec.Replicas != nil {
+		replicas = *d.Spec.Replicas
+	}
+
+	return models.Deployment{
+		Name:              d.Name,
+		Namespace:         d.Namespace,
+		Replicas:          replicas,
+		AvailableReplicas: d.Status.AvailableReplicas,
+		CreatedAt:         d.CreationTimestamp.Time.Format(time.RFC3339),
+		Strategy:          string(d.Spec.Strategy.Type),
+		Paused:            d.Spec.Paused,
+		RestartedAt:       d.Spec.Template.Annotations[restartedAtAnnotation],
+		Labels:            d.Labels,
+	}
+}
@@ -0,0 +1,428 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/gorilla/mux"
+	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	defaultDrainTimeout = 5 * time.Minute
+	drainRetryInterval  = 5 * time.Second
+)
+
+// Drain job and pod states reported in models.DrainJob
+const (
+	drainRunning   = "Running"
+	drainSucceeded = "Succeeded"
+	drainFailed    = "Failed"
+	drainTimedOut  = "TimedOut"
+	drainCancelled = "Cancelled"
+
+	drainPodPending      = "Pending"
+	drainPodEvicting     = "Evicting"
+	drainPodEvicted      = "Evicted"
+	drainPodBlockedByPDB = "BlockedByPDB"
+	drainPodSkipped      = "Skipped"
+	drainPodFailed       = "Failed"
+)
+
+// drainJob tracks a drain running in the background. changed is closed and replaced on every
+// update so that streaming clients can wait for the next change.
+type drainJob struct {
+	mu      sync.Mutex
+	state   models.DrainJob
+	changed chan struct{}
+	cancel  context.CancelFunc
+}
+
+// drainJobs holds the most recent drain of each node
+var drainJobs = struct {
+	sync.Mutex
+	byNode map[string]*drainJob
+}{byNode: make(map[string]*drainJob)}
+
+// DrainNode cordons a node and starts evicting its pods in the background
+func DrainNode(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name := vars["name"]
+
+		var req models.DrainNodeRequest
+		if err := decodeOptionalBody(r, &req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		var errs field.ErrorList
+		if req.GracePeriodSeconds != nil && *req.GracePeriodSeconds < 0 {
+			errs = append(errs, field.Invalid(field.NewPath("gracePeriodSeconds"), *req.GracePeriodSeconds, "must be non-negative"))
+		}
+		if req.TimeoutSeconds < 0 {
+			errs = append(errs, field.Invalid(field.NewPath("timeoutSeconds"), req.TimeoutSeconds, "must be non-negative"))
+		}
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		if _, err := clientset.CoreV1().Nodes().Get(r.Context(), name, metav1.GetOptions{}); err != nil {
+			log.Printf(utils.LogFailedGetNode, err)
+			http.Error(w, utils.MsgNodeNotFound, http.StatusNotFound)
+			return
+		}
+
+		timeout := defaultDrainTimeout
+		if req.TimeoutSeconds > 0 {
+			timeout = time.Duration(req.TimeoutSeconds) * time.Second
+		}
+
+		drainJobs.Lock()
+		if existing, ok := drainJobs.byNode[name]; ok {
+			if state, _ := existing.snapshot(); state.Status == drainRunning {
+				drainJobs.Unlock()
+				http.Error(w, utils.MsgDrainInProgress, http.StatusConflict)
+				return
+			}
+		}
+		// The drain outlives the request, so it must not use the request context
+		ctx, cancel := context.WithTimeout(context.Background(), timeout)
+		job := &drainJob{
+			state: models.DrainJob{
+				ID:        fmt.Sprintf("%s-%d", name, time.Now().Unix()),
+				Node:      name,
+				Status:    drainRunning,
+				StartedAt: time.Now().Format(time.RFC3339),
+				Pods:      []models.DrainPod{},
+			},
+			changed: make(chan struct{}),
+			cancel:  cancel,
+		}
+		drainJobs.byNode[name] = job
+		drainJobs.Unlock()
+
+		log.Printf(utils.LogDrainStarted, job.state.ID, name)
+		go runDrain(ctx, clientset, job, req)
+
+		state, _ := job.snapshot()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(state); err != nil {
+			log.Printf(utils.LogFailedEncodeDrainJob, err)
+		}
+	}
+}
+
+// GetNodeDrain returns the progress of the most recent drain of a node
+func GetNodeDrain() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		job := lookupDrainJob(mux.Vars(r)["name"])
+		if job == nil {
+			http.Error(w, utils.MsgDrainNotFound, http.StatusNotFound)
+			return
+		}
+
+		state, _ := job.snapshot()
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(state); err != nil {
+			log.Printf(utils.LogFailedEncodeDrainJob, err)
+		}
+	}
+}
+
+// StreamNodeDrain streams drain progress as server-sent events until the drain finishes
+func StreamNodeDrain() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		job := lookupDrainJob(mux.Vars(r)["name"])
+		if job == nil {
+			http.Error(w, utils.MsgDrainNotFound, http.StatusNotFound)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, utils.MsgStreamingUnsupported, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		for {
+			state, changed := job.snapshot()
+			if state.Status != drainRunning {
+				writeSSE(w, flusher, "finished", state)
+				return
+			}
+			if err := writeSSE(w, flusher, "progress", state); err != nil {
+				return
+			}
+
+			select {
+			case <-r.Context().Done():
+				return
+			case <-changed:
+			}
+		}
+	}
+}
+
+// CancelNodeDrain stops a running drain; pods already evicted stay evicted and the node stays cordoned
+func CancelNodeDrain() http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		job := lookupDrainJob(mux.Vars(r)["name"])
+		if job == nil {
+			http.Error(w, utils.MsgDrainNotFound, http.StatusNotFound)
+			return
+		}
+		job.cancel()
+
+		state, _ := job.snapshot()
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusAccepted)
+		if err := json.NewEncoder(w).Encode(state); err != nil {
+			log.Printf(utils.LogFailedEncodeDrainJob, err)
+		}
+	}
+}
+
+// lookupDrainJob returns the most recent drain of a node, or nil
+func lookupDrainJob(node string) *drainJob {
+	drainJobs.Lock()
+	defer drainJobs.Unlock()
+	return drainJobs.byNode[node]
+}
+
+// snapshot returns a copy of the job state and the channel closed on its next change
+func (j *drainJob) snapshot() (models.DrainJob, <-chan struct{}) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	state := j.state
+	state.Pods = make([]models.DrainPod, len(j.state.Pods))
+	copy(state.Pods, j.state.Pods)
+	return state, j.changed
+}
+
+// update applies fn to the job state, recounts the pod totals and wakes up streaming clients
+func (j *drainJob) update(fn func(state *models.DrainJob)) {
+	j.mu.Lock()
+	defer j.mu.Unlock()
+
+	fn(&j.state)
+	j.state.Evicted, j.state.Pending, j.state.BlockedByPDB, j.state.Skipped = 0, 0, 0, 0
+	for _, pod := range j.state.Pods {
+		switch pod.Status {
+		case drainPodEvicted:
+			j.state.Evicted++
+		case drainPodPending, drainPodEvicting:
+			j.state.Pending++
+		case drainPodBlockedByPDB:
+			j.state.BlockedByPDB++
+		case drainPodSkipped:
+			j.state.Skipped++
+		}
+	}
+
+	close(j.changed)
+	j.changed = make(chan struct{})
+}
+
+// finish marks the job as done
+func (j *drainJob) finish(status, message string) {
+	j.update(func(state *models.DrainJob) {
+		state.Status = status
+		state.Message = message
+		state.FinishedAt = time.Now().Format(time.RFC3339)
+	})
+	log.Printf(utils.LogDrainFinished, j.state.ID, j.state.Node, status, message)
+}
+
+// runDrain cordons the node and evicts its pods until all are gone, the context ends or a pod cannot be evicted
+func runDrain(ctx context.Context, clientset *kubernetes.Clientset, job *drainJob, req models.DrainNodeRequest) {
+	defer job.cancel()
+	node := job.state.Node
+
+	if _, err := patchNodeUnschedulable(ctx, clientset, node, true); err != nil {
+		log.Printf(utils.LogFailedCordonNode, node, err)
+		job.finish(drainFailed, "failed to cordon node: "+err.Error())
+		return
+	}
+
+	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", node).String(),
+	})
+	if err != nil {
+		log.Printf(utils.LogFailedListPods, err)
+		job.finish(drainFailed, "failed to list pods: "+err.Error())
+		return
+	}
+
+	// uids lets the eviction loop recognise a pod that was replaced by one with the same name
+	uids := make(map[string]string, len(pods.Items))
+	var blockers []string
+	job.update(func(state *models.DrainJob) {
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			entry := models.DrainPod{Namespace: pod.Namespace, Name: pod.Name, Status: drainPodPending}
+			if reason := drainSkipReason(pod); reason != "" {
+				entry.Status = drainPodSkipped
+				entry.Reason = reason
+			} else if reason := drainBlockReason(pod, req); reason != "" {
+				entry.Status = drainPodFailed
+				entry.Reason = reason
+				blockers = append(blockers, pod.Namespace+"/"+pod.Name)
+			} else if pod.DeletionTimestamp != nil {
+				entry.Status = drainPodEvicting
+			}
+			uids[pod.Namespace+"/"+pod.Name] = string(pod.UID)
+			state.Pods = append(state.Pods, entry)
+		}
+	})
+	// Like kubectl drain, refuse to start evicting when some pods would need an explicit override
+	if len(blockers) > 0 {
+		job.finish(drainFailed, "cannot evict pods: "+strings.Join(blockers, ", "))
+		return
+	}
+
+	ticker := time.NewTicker(drainRetryInterval)
+	defer ticker.Stop()
+	for {
+		state, _ := job.snapshot()
+		remaining := 0
+		for i, pod := range state.Pods {
+			switch pod.Status {
+			case drainPodPending, drainPodBlockedByPDB:
+				remaining++
+				updated := evictDrainPod(ctx, clientset, pod, req.GracePeriodSeconds)
+				job.update(func(s *models.DrainJob) { s.Pods[i] = updated })
+			case drainPodEvicting:
+				remaining++
+				current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+				if apierrors.IsNotFound(err) || (err == nil && string(current.UID) != uids[pod.Namespace+"/"+pod.Name]) {
+					remaining--
+					job.update(func(s *models.DrainJob) {
+						s.Pods[i].Status = drainPodEvicted
+						s.Pods[i].Reason = ""
+					})
+				}
+			}
+		}
+		if remaining == 0 {
+			job.finish(drainSucceeded, "")
+			return
+		}
+
+		select {
+		case <-ctx.Done():
+			if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+				job.finish(drainTimedOut, "timed out waiting for pods to be evicted")
+			} else {
+				job.finish(drainCancelled, "drain was cancelled")
+			}
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// evictDrainPod requests the eviction of a pod and returns its new drain state
+func evictDrainPod(ctx context.Context, clientset *kubernetes.Clientset, pod models.DrainPod, gracePeriod *int64) models.DrainPod {
+	eviction := &policyv1.Eviction{
+		ObjectMeta:    metav1.ObjectMeta{Name: pod.Name, Namespace: pod.Namespace},
+		DeleteOptions: &metav1.DeleteOptions{GracePeriodSeconds: gracePeriod},
+	}
+
+	err := clientset.PolicyV1().Evictions(pod.Namespace).Evict(ctx, eviction)
+	switch {
+	case err == nil:
+		pod.Status = drainPodEvicting
+		pod.Reason = ""
+		pod.DisruptionBudgets = nil
+	case apierrors.IsNotFound(err):
+		pod.Status = drainPodEvicted
+		pod.Reason = ""
+		pod.DisruptionBudgets = nil
+	case apierrors.IsTooManyRequests(err):
+		// The eviction API answers 429 when a PodDisruptionBudget allows no more disruptions
+		pod.Status = drainPodBlockedByPDB
+		pod.Reason = err.Error()
+		pod.DisruptionBudgets = blockingDisruptionBudgets(ctx, clientset, pod)
+	default:
+		log.Printf(utils.LogFailedEvictPod, pod.Namespace+"/"+pod.Name, err)
+		pod.Status = drainPodPending
+		pod.Reason = err.Error()
+	}
+	return pod
+}
+
+// blockingDisruptionBudgets returns the PodDisruptionBudgets selecting a pod that allow no disruptions
+func blockingDisruptionBudgets(ctx context.Context, clientset *kubernetes.Clientset, pod models.DrainPod) []string {
+	current, err := clientset.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+	if err != nil {
+		return nil
+	}
+	budgets, err := clientset.PolicyV1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Printf(utils.LogFailedListDisruptionBudgets, pod.Namespace, err)
+		return nil
+	}
+
+	var names []string
+	for _, pdb := range budgets.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() || !selector.Matches(labels.Set(current.Labels)) {
+			continue
+		}
+		if pdb.Status.DisruptionsAllowed <= 0 {
+			names = append(names, pdb.Name)
+		}
+	}
+	return names
+}
+
+// drainSkipReason explains why a pod is left on the node, or returns "" when it should be evicted
+func drainSkipReason(pod *corev1.Pod) string {
+	if _, ok := pod.Annotations[corev1.MirrorPodAnnotationKey]; ok {
+		return "mirror pod managed by the kubelet"
+	}
+	if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "DaemonSet" {
+		return "managed by DaemonSet " + owner.Name
+	}
+	return ""
+}
+
+// drainBlockReason explains why a pod cannot be evicted without an explicit override, or returns ""
+func drainBlockReason(pod *corev1.Pod, req models.DrainNodeRequest) string {
+	finished := pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+	if metav1.GetControllerOf(pod) == nil && !finished && !req.Force {
+		return "not managed by a controller; set force to evict it"
+	}
+	if !req.DeleteEmptyDirData {
+		for _, volume := range pod.Spec.Volumes {
+			if volume.EmptyDir != nil {
+				return "uses emptyDir volume " + volume.Name + "; set deleteEmptyDirData to evict it"
+			}
+		}
+	}
+	return ""
+}
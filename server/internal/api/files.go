@@ -0,0 +1,402 @@
+package api
+
+import (
+	"archive/tar"
+	"archive/zip"
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"mime/multipart"
+	"net/http"
+	"os"
+	"path"
+	"strconv"
+	"strings"
+
+	"github.com/gorilla/mux"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/remotecommand"
+	utilexec "k8s.io/client-go/util/exec"
+)
+
+const (
+	defaultMaxDownloadBytes = 512 << 20 // 512 MiB
+	defaultMaxUploadBytes   = 64 << 20  // 64 MiB
+
+	// multipart parts above this size are spooled to disk
+	uploadMemoryBytes = 8 << 20
+)
+
+var errFileTooLarge = errors.New("file size limit exceeded")
+
+// DownloadPodFiles streams a file or directory out of a container as a tar or zip archive
+func DownloadPodFiles(clientset *kubernetes.Clientset, restConfig *rest.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		srcPath, err := validateContainerPath(r.URL.Query().Get("path"))
+		if err != nil || srcPath == "/" {
+			http.Error(w, utils.MsgInvalidContainerPath, http.StatusBadRequest)
+			return
+		}
+
+		format := r.URL.Query().Get("format")
+		if format == "" {
+			format = "tar"
+		}
+		if format != "tar" && format != "zip" {
+			http.Error(w, utils.MsgInvalidArchiveFormat, http.StatusBadRequest)
+			return
+		}
+
+		container, status, msg := resolveContainer(r.Context(), clientset, namespace, name, r.URL.Query().Get("container"))
+		if status != 0 {
+			http.Error(w, msg, status)
+			return
+		}
+
+		// tar still writes an empty archive for a missing path, so check it exists first
+		if status, msg := checkContainerPath(r.Context(), clientset, restConfig, namespace, name, container, srcPath); status != 0 {
+			http.Error(w, msg, status)
+			return
+		}
+
+		// Reject oversized downloads while an error status can still be sent; the limited writer
+		// below stays as a backstop for containers without du
+		maxBytes := envInt64("POD_FILES_MAX_DOWNLOAD_BYTES", defaultMaxDownloadBytes)
+		if size, ok := containerPathSize(r.Context(), clientset, restConfig, namespace, name, container, srcPath); ok && size > maxBytes {
+			http.Error(w, utils.MsgPodFilesTooLarge, http.StatusRequestEntityTooLarge)
+			return
+		}
+
+		// Same invocation kubectl cp uses: archive the base name relative to its parent
+		cmd := []string{"tar", "cf", "-", "-C", path.Dir(srcPath), path.Base(srcPath)}
+
+		pr, pw := io.Pipe()
+		stderr := &bytes.Buffer{}
+
+		go func() {
+			err := execInContainer(r.Context(), clientset, restConfig, namespace, name, container, cmd,
+				nil, &limitedWriter{w: pw, remaining: maxBytes}, stderr)
+			pw.CloseWithError(err)
+		}()
+		defer pr.Close()
+
+		// Wait for the first bytes so exec failures can still be reported as an error status
+		stream := bufio.NewReader(pr)
+		if _, err := stream.Peek(1); err != nil {
+			log.Printf(utils.LogFailedDownloadPodFiles, srcPath, name, err, stderr.String())
+			if errors.Is(err, errFileTooLarge) {
+				http.Error(w, utils.MsgPodFilesTooLarge, http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, utils.MsgFailedDownloadPodFiles, http.StatusInternalServerError)
+			return
+		}
+
+		filename := path.Base(srcPath) + "." + format
+		w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+
+		if format == "tar" {
+			w.Header().Set("Content-Type", "application/x-tar")
+			_, err = io.Copy(w, stream)
+		} else {
+			w.Header().Set("Content-Type", "application/zip")
+			err = tarToZip(stream, w)
+		}
+		if err != nil {
+			// The 200 is already out; drop the connection so the client sees a failed download
+			// instead of a truncated archive that looks complete
+			log.Printf(utils.LogFailedStreamPodFiles, srcPath, name, err)
+			panic(http.ErrAbortHandler)
+		}
+	}
+}
+
+// UploadPodFiles extracts multipart-uploaded files into a directory inside a container
+func UploadPodFiles(clientset *kubernetes.Clientset, restConfig *rest.Config) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		maxBytes := envInt64("POD_FILES_MAX_UPLOAD_BYTES", defaultMaxUploadBytes)
+		r.Body = http.MaxBytesReader(w, r.Body, maxBytes)
+		if err := r.ParseMultipartForm(uploadMemoryBytes); err != nil {
+			var maxErr *http.MaxBytesError
+			if errors.As(err, &maxErr) {
+				http.Error(w, utils.MsgPodFilesTooLarge, http.StatusRequestEntityTooLarge)
+				return
+			}
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+		defer r.MultipartForm.RemoveAll()
+
+		destDir, err := validateContainerPath(r.FormValue("path"))
+		if err != nil {
+			http.Error(w, utils.MsgInvalidContainerPath, http.StatusBadRequest)
+			return
+		}
+
+		files := r.MultipartForm.File["files"]
+		if len(files) == 0 {
+			http.Error(w, utils.MsgNoFilesUploaded, http.StatusBadRequest)
+			return
+		}
+		for _, fh := range files {
+			if !isPlainFileName(fh.Filename) {
+				http.Error(w, utils.MsgInvalidContainerPath, http.StatusBadRequest)
+				return
+			}
+		}
+
+		container, status, msg := resolveContainer(r.Context(), clientset, namespace, name, r.FormValue("container"))
+		if status != 0 {
+			http.Error(w, msg, status)
+			return
+		}
+
+		pr, pw := io.Pipe()
+		go func() {
+			pw.CloseWithError(writeUploadTar(pw, files))
+		}()
+
+		stderr := &bytes.Buffer{}
+		cmd := []string{"tar", "xmf", "-", "-C", destDir}
+		if err := execInContainer(r.Context(), clientset, restConfig, namespace, name, container, cmd, pr, io.Discard, stderr); err != nil {
+			pr.CloseWithError(err)
+			log.Printf(utils.LogFailedUploadPodFiles, destDir, name, err, stderr.String())
+			http.Error(w, utils.MsgFailedUploadPodFiles, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.PodFileUploadResponse{
+			Container: container,
+			Path:      destDir,
+			Files:     make([]models.PodFile, 0, len(files)),
+		}
+		for _, fh := range files {
+			response.Files = append(response.Files, models.PodFile{
+				Name: path.Join(destDir, fh.Filename),
+				Size: fh.Size,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodePodFileUpload, err)
+		}
+	}
+}
+
+// resolveContainer checks the pod exists and picks the target container,
+// defaulting the same way kubectl does. A non-zero status signals failure.
+func resolveContainer(ctx context.Context, clientset *kubernetes.Clientset, namespace, name, container string) (string, int, string) {
+	pod, err := clientset.CoreV1().Pods(namespace).Get(ctx, name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf(utils.LogFailedGetPod, err)
+		return "", http.StatusNotFound, utils.MsgPodNotFound
+	}
+
+	if container == "" {
+		if def := pod.Annotations["kubectl.kubernetes.io/default-container"]; def != "" {
+			container = def
+		} else if len(pod.Spec.Containers) > 0 {
+			container = pod.Spec.Containers[0].Name
+		}
+	}
+
+	for _, c := range pod.Spec.Containers {
+		if c.Name == container {
+			if pod.Status.Phase != corev1.PodRunning {
+				return "", http.StatusConflict, utils.MsgPodNotRunning
+			}
+			return container, 0, ""
+		}
+	}
+	return "", http.StatusBadRequest, utils.MsgContainerNotFound
+}
+
+// checkContainerPath runs test -e for a path inside a container and returns a non-zero status with
+// a message when the path is missing or the check could not run
+func checkContainerPath(ctx context.Context, clientset *kubernetes.Clientset, restConfig *rest.Config, namespace, pod, container, p string) (int, string) {
+	stderr := &bytes.Buffer{}
+	err := execInContainer(ctx, clientset, restConfig, namespace, pod, container, []string{"test", "-e", p}, nil, nil, stderr)
+	if err == nil {
+		return 0, ""
+	}
+	var exitErr utilexec.ExitError
+	if errors.As(err, &exitErr) && exitErr.ExitStatus() == 1 {
+		return http.StatusNotFound, utils.MsgPodFileNotFound
+	}
+	log.Printf(utils.LogFailedDownloadPodFiles, p, pod, err, stderr.String())
+	return http.StatusInternalServerError, utils.MsgFailedDownloadPodFiles
+}
+
+// containerPathSize returns the disk usage of a path inside a container using du -sk. ok is false
+// when du is unavailable or fails, leaving the size limit to be enforced while streaming.
+func containerPathSize(ctx context.Context, clientset *kubernetes.Clientset, restConfig *rest.Config, namespace, pod, container, p string) (size int64, ok bool) {
+	stdout := &bytes.Buffer{}
+	if err := execInContainer(ctx, clientset, restConfig, namespace, pod, container, []string{"du", "-sk", p}, nil, stdout, io.Discard); err != nil {
+		return 0, false
+	}
+	fields := strings.Fields(stdout.String())
+	if len(fields) == 0 {
+		return 0, false
+	}
+	kib, err := strconv.ParseInt(fields[0], 10, 64)
+	if err != nil {
+		return 0, false
+	}
+	return kib << 10, true
+}
+
+// execInContainer runs a command in a container and wires up its standard streams
+func execInContainer(ctx context.Context, clientset *kubernetes.Clientset, restConfig *rest.Config, namespace, pod, container string, cmd []string, stdin io.Reader, stdout, stderr io.Writer) error {
+	req := clientset.CoreV1().RESTClient().Post().
+		Resource("pods").
+		Namespace(namespace).
+		Name(pod).
+		SubResource("exec").
+		VersionedParams(&corev1.PodExecOptions{
+			Container: container,
+			Command:   cmd,
+			Stdin:     stdin != nil,
+			Stdout:    stdout != nil,
+			Stderr:    stderr != nil,
+		}, scheme.ParameterCodec)
+
+	executor, err := remotecommand.NewSPDYExecutor(restConfig, "POST", req.URL())
+	if err != nil {
+		return err
+	}
+
+	return executor.StreamWithContext(ctx, remotecommand.StreamOptions{
+		Stdin:  stdin,
+		Stdout: stdout,
+		Stderr: stderr,
+	})
+}
+
+// validateContainerPath cleans an absolute path inside a container, rejecting traversal
+func validateContainerPath(p string) (string, error) {
+	if p == "" || !strings.HasPrefix(p, "/") || strings.ContainsRune(p, 0) {
+		return "", fmt.Errorf("invalid container path: %q", p)
+	}
+	for _, part := range strings.Split(p, "/") {
+		if part == ".." {
+			return "", fmt.Errorf("path traversal not allowed: %q", p)
+		}
+	}
+	return path.Clean(p), nil
+}
+
+// isPlainFileName reports whether name is a single path element safe to extract
+func isPlainFileName(name string) bool {
+	return name != "" && name != "." && name != ".." &&
+		!strings.ContainsAny(name, "/\\\x00")
+}
+
+// writeUploadTar packs the uploaded files into a flat tar stream
+func writeUploadTar(w io.Writer, files []*multipart.FileHeader) error {
+	tw := tar.NewWriter(w)
+	for _, fh := range files {
+		f, err := fh.Open()
+		if err != nil {
+			return err
+		}
+		hdr := &tar.Header{
+			Name: fh.Filename,
+			Mode: 0644,
+			Size: fh.Size,
+		}
+		if err := tw.WriteHeader(hdr); err != nil {
+			f.Close()
+			return err
+		}
+		if _, err := io.Copy(tw, f); err != nil {
+			f.Close()
+			return err
+		}
+		f.Close()
+	}
+	return tw.Close()
+}
+
+// tarToZip re-encodes a tar stream as a zip archive
+func tarToZip(r io.Reader, w io.Writer) error {
+	tr := tar.NewReader(r)
+	zw := zip.NewWriter(w)
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return err
+		}
+
+		switch hdr.Typeflag {
+		case tar.TypeDir:
+			if _, err := zw.Create(strings.TrimSuffix(hdr.Name, "/") + "/"); err != nil {
+				return err
+			}
+		case tar.TypeReg:
+			zh, err := zip.FileInfoHeader(hdr.FileInfo())
+			if err != nil {
+				return err
+			}
+			zh.Name = hdr.Name
+			zh.Method = zip.Deflate
+			fw, err := zw.CreateHeader(zh)
+			if err != nil {
+				return err
+			}
+			if _, err := io.Copy(fw, tr); err != nil {
+				return err
+			}
+		}
+		// Symlinks and special files have no portable zip representation and are skipped
+	}
+	return zw.Close()
+}
+
+// limitedWriter fails once more than remaining bytes have been written
+type limitedWriter struct {
+	w         io.Writer
+	remaining int64
+}
+
+func (l *limitedWriter) Write(p []byte) (int, error) {
+	if int64(len(p)) > l.remaining {
+		return 0, errFileTooLarge
+	}
+	n, err := l.w.Write(p)
+	l.remaining -= int64(n)
+	return n, err
+}
+
+// envInt64 reads a positive integer from the environment, falling back to def
+func envInt64(key string, def int64) int64 {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := strconv.ParseInt(v, 10, 64); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return def
+}
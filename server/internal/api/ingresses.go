@@ -0,0 +1,631 @@
+package api
+
+import (
+	"encoding/json"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+// defaultIngressClassAnnotation marks the ingress class used when an ingress names none
+const defaultIngressClassAnnotation = "ingressclass.kubernetes.io/is-default-class"
+
+// ListIngresses returns all ingresses with their backends resolved to services
+func ListIngresses(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		ingresses, err := clientset.NetworkingV1().Ingresses("").List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListIngresses, err)
+			http.Error(w, utils.MsgFailedListIngresses, http.StatusInternalServerError)
+			return
+		}
+
+		// Backends are still listed by name when services cannot be resolved
+		var services map[string]*corev1.Service
+		if list, err := clientset.CoreV1().Services("").List(r.Context(), metav1.ListOptions{}); err != nil {
+			log.Printf(utils.LogFailedResolveIngressBackends, "*", err)
+		} else {
+			services = servicesByKey(list.Items)
+		}
+
+		response := models.IngressListResponse{Items: make([]models.Ingress, 0, len(ingresses.Items))}
+		for _, ing := range ingresses.Items {
+			response.Items = append(response.Items, toIngress(&ing, services))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeIngressesList, err)
+		}
+	}
+}
+
+// GetIngress returns ingress details with its backends resolved to services
+func GetIngress(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		ingress, err := clientset.NetworkingV1().Ingresses(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetIngress, err)
+			http.Error(w, utils.MsgIngressNotFound, http.StatusNotFound)
+			return
+		}
+
+		var services map[string]*corev1.Service
+		if list, err := clientset.CoreV1().Services(namespace).List(r.Context(), metav1.ListOptions{}); err != nil {
+			log.Printf(utils.LogFailedResolveIngressBackends, name, err)
+		} else {
+			services = servicesByKey(list.Items)
+		}
+
+		response := toIngress(ingress, services)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeIngress, err)
+		}
+	}
+}
+
+// GetIngressRoutes returns the host -> path -> service -> pods routing tree of an ingress
+func GetIngressRoutes(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		ingress, err := clientset.NetworkingV1().Ingresses(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetIngress, err)
+			http.Error(w, utils.MsgIngressNotFound, http.StatusNotFound)
+			return
+		}
+
+		services, err := clientset.CoreV1().Services(namespace).List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedResolveIngressBackends, name, err)
+			http.Error(w, utils.MsgFailedGetIngressRoutes, http.StatusInternalServerError)
+			return
+		}
+		pods, err := clientset.CoreV1().Pods(namespace).List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedResolveIngressBackends, name, err)
+			http.Error(w, utils.MsgFailedGetIngressRoutes, http.StatusInternalServerError)
+			return
+		}
+
+		response := ingressRouteTree(ingress, servicesByKey(services.Items), pods.Items)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeIngressRoutes, err)
+		}
+	}
+}
+
+// CreateIngress creates a new ingress
+func CreateIngress(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.CreateIngressRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		ingress, errs := buildIngress(req)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		created, err := clientset.NetworkingV1().Ingresses(req.Namespace).Create(r.Context(), ingress, metav1.CreateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedCreateIngress, err)
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			if apierrors.IsAlreadyExists(err) {
+				http.Error(w, utils.MsgIngressAlreadyExists, http.StatusConflict)
+				return
+			}
+			http.Error(w, utils.MsgFailedCreateIngress, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(toIngress(created, nil)); err != nil {
+			log.Printf(utils.LogFailedEncodeCreatedIngress, err)
+		}
+	}
+}
+
+// DeleteIngress deletes an ingress
+func DeleteIngress(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		err := clientset.NetworkingV1().Ingresses(namespace).Delete(r.Context(), name, metav1.DeleteOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedDeleteIngress, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgIngressNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedDeleteIngress, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ListIngressClasses returns all ingress classes
+func ListIngressClasses(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		classes, err := clientset.NetworkingV1().IngressClasses().List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListIngressClasses, err)
+			http.Error(w, utils.MsgFailedListIngressClasses, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.IngressClassListResponse{Items: make([]models.IngressClass, 0, len(classes.Items))}
+		for _, c := range classes.Items {
+			response.Items = append(response.Items, toIngressClass(&c))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeIngressClassesList, err)
+		}
+	}
+}
+
+// GetIngressClass returns ingress class details
+func GetIngressClass(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["name"]
+
+		class, err := clientset.NetworkingV1().IngressClasses().Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetIngressClass, err)
+			http.Error(w, utils.MsgIngressClassNotFound, http.StatusNotFound)
+			return
+		}
+
+		response := toIngressClass(class)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeIngressClass, err)
+		}
+	}
+}
+
+// CreateIngressClass creates a new ingress class
+func CreateIngressClass(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.CreateIngressClassRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		var errs field.ErrorList
+		errs = append(errs, validateObjectName(req.Name, field.NewPath("name"))...)
+		if req.Controller == "" {
+			errs = append(errs, field.Required(field.NewPath("controller"), "controller is required"))
+		} else {
+			errs = append(errs, validation.IsDomainPrefixedPath(field.NewPath("controller"), req.Controller)...)
+		}
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		class := &networkingv1.IngressClass{
+			ObjectMeta: metav1.ObjectMeta{Name: req.Name},
+			Spec:       networkingv1.IngressClassSpec{Controller: req.Controller},
+		}
+		if req.IsDefault {
+			class.Annotations = map[string]string{defaultIngressClassAnnotation: "true"}
+		}
+
+		created, err := clientset.NetworkingV1().IngressClasses().Create(r.Context(), class, metav1.CreateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedCreateIngressClass, err)
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			if apierrors.IsAlreadyExists(err) {
+				http.Error(w, utils.MsgIngressClassAlreadyExists, http.StatusConflict)
+				return
+			}
+			http.Error(w, utils.MsgFailedCreateIngressClass, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(toIngressClass(created)); err != nil {
+			log.Printf(utils.LogFailedEncodeCreatedIngressClass, err)
+		}
+	}
+}
+
+// DeleteIngressClass deletes an ingress class
+func DeleteIngressClass(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["name"]
+
+		err := clientset.NetworkingV1().IngressClasses().Delete(r.Context(), name, metav1.DeleteOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedDeleteIngressClass, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgIngressClassNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedDeleteIngressClass, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// buildIngress converts and validates a create request into an ingress object
+func buildIngress(req models.CreateIngressRequest) (*networkingv1.Ingress, field.ErrorList) {
+	var errs field.ErrorList
+
+	errs = append(errs, validateObjectName(req.Name, field.NewPath("name"))...)
+	errs = append(errs, validateNamespace(req.Namespace, field.NewPath("namespace"))...)
+	errs = append(errs, validateLabels(req.Labels, field.NewPath("labels"))...)
+	if req.IngressClassName != "" {
+		errs = append(errs, validateObjectName(req.IngressClassName, field.NewPath("ingressClassName"))...)
+	}
+	if len(req.Rules) == 0 && req.DefaultBackend == nil {
+		errs = append(errs, field.Required(field.NewPath("rules"), "at least one rule or a defaultBackend is required"))
+	}
+
+	ingress := &networkingv1.Ingress{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        req.Name,
+			Namespace:   req.Namespace,
+			Labels:      req.Labels,
+			Annotations: req.Annotations,
+		},
+	}
+	if req.IngressClassName != "" {
+		className := req.IngressClassName
+		ingress.Spec.IngressClassName = &className
+	}
+
+	if req.DefaultBackend != nil {
+		backend, bErrs := buildIngressBackend(*req.DefaultBackend, field.NewPath("defaultBackend"))
+		errs = append(errs, bErrs...)
+		ingress.Spec.DefaultBackend = &backend
+	}
+
+	for i, rule := range req.Rules {
+		rulePath := field.NewPath("rules").Index(i)
+		errs = append(errs, validateIngressHost(rule.Host, rulePath.Child("host"))...)
+		if len(rule.Paths) == 0 {
+			errs = append(errs, field.Required(rulePath.Child("paths"), "at least one path is required"))
+		}
+
+		httpRule := &networkingv1.HTTPIngressRuleValue{}
+		for j, p := range rule.Paths {
+			pathPath := rulePath.Child("paths").Index(j)
+			if !strings.HasPrefix(p.Path, "/") {
+				errs = append(errs, field.Invalid(pathPath.Child("path"), p.Path, "must be an absolute path"))
+			}
+
+			pathType := networkingv1.PathType(p.PathType)
+			switch pathType {
+			case "":
+				pathType = networkingv1.PathTypePrefix
+			case networkingv1.PathTypeExact, networkingv1.PathTypePrefix, networkingv1.PathTypeImplementationSpecific:
+			default:
+				errs = append(errs, field.NotSupported(pathPath.Child("pathType"), p.PathType, []string{"Exact", "Prefix", "ImplementationSpecific"}))
+			}
+
+			backend, bErrs := buildIngressBackend(p.IngressBackendSpec, pathPath)
+			errs = append(errs, bErrs...)
+			httpRule.Paths = append(httpRule.Paths, networkingv1.HTTPIngressPath{
+				Path:     p.Path,
+				PathType: &pathType,
+				Backend:  backend,
+			})
+		}
+
+		ingress.Spec.Rules = append(ingress.Spec.Rules, networkingv1.IngressRule{
+			Host:             rule.Host,
+			IngressRuleValue: networkingv1.IngressRuleValue{HTTP: httpRule},
+		})
+	}
+
+	for i, t := range req.TLS {
+		tlsPath := field.NewPath("tls").Index(i)
+		if t.SecretName != "" {
+			errs = append(errs, validateObjectName(t.SecretName, tlsPath.Child("secretName"))...)
+		}
+		for j, h := range t.Hosts {
+			errs = append(errs, validateIngressHost(h, tlsPath.Child("hosts").Index(j))...)
+		}
+		ingress.Spec.TLS = append(ingress.Spec.TLS, networkingv1.IngressTLS{
+			Hosts:      t.Hosts,
+			SecretName: t.SecretName,
+		})
+	}
+
+	return ingress, errs
+}
+
+// buildIngressBackend converts a service backend whose port is given by number or name
+func buildIngressBackend(spec models.IngressBackendSpec, fldPath *field.Path) (networkingv1.IngressBackend, field.ErrorList) {
+	var errs field.ErrorList
+
+	if spec.ServiceName == "" {
+		errs = append(errs, field.Required(fldPath.Child("serviceName"), "service name is required"))
+	} else {
+		for _, msg := range validation.IsDNS1035Label(spec.ServiceName) {
+			errs = append(errs, field.Invalid(fldPath.Child("serviceName"), spec.ServiceName, msg))
+		}
+	}
+
+	port := networkingv1.ServiceBackendPort{}
+	if number, err := strconv.Atoi(spec.ServicePort); err == nil {
+		for _, msg := range validation.IsValidPortNum(number) {
+			errs = append(errs, field.Invalid(fldPath.Child("servicePort"), spec.ServicePort, msg))
+		}
+		port.Number = int32(number)
+	} else if spec.ServicePort == "" {
+		errs = append(errs, field.Required(fldPath.Child("servicePort"), "service port number or name is required"))
+	} else {
+		for _, msg := range validation.IsValidPortName(spec.ServicePort) {
+			errs = append(errs, field.Invalid(fldPath.Child("servicePort"), spec.ServicePort, msg))
+		}
+		port.Name = spec.ServicePort
+	}
+
+	return networkingv1.IngressBackend{
+		Service: &networkingv1.IngressServiceBackend{Name: spec.ServiceName, Port: port},
+	}, errs
+}
+
+// validateIngressHost allows an empty host, a DNS name or a leading wildcard such as *.example.com
+func validateIngressHost(host string, fldPath *field.Path) field.ErrorList {
+	if host == "" {
+		return nil
+	}
+	var errs field.ErrorList
+	if strings.HasPrefix(host, "*.") {
+		for _, msg := range validation.IsWildcardDNS1123Subdomain(host) {
+			errs = append(errs, field.Invalid(fldPath, host, msg))
+		}
+		return errs
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(host) {
+		errs = append(errs, field.Invalid(fldPath, host, msg))
+	}
+	return errs
+}
+
+// ingressRouteTree groups an ingress by host and expands every backend into the pods behind it
+func ingressRouteTree(ing *networkingv1.Ingress, services map[string]*corev1.Service, pods []corev1.Pod) models.IngressRouteTree {
+	tree := models.IngressRouteTree{
+		Name:      ing.Name,
+		Namespace: ing.Namespace,
+		Hosts:     []models.IngressRouteHost{},
+	}
+
+	routePath := func(path, pathType string, backend *networkingv1.IngressBackend) models.IngressRoutePath {
+		resolved := ingressBackend(ing.Namespace, backend, services)
+		route := models.IngressRoutePath{
+			Path:     path,
+			PathType: pathType,
+			Backend:  resolved,
+			Pods:     []models.RoutePod{},
+		}
+		if svc, ok := services[ing.Namespace+"/"+resolved.ServiceName]; ok {
+			route.Pods = servicePods(svc, pods)
+		}
+		return route
+	}
+
+	hostIndex := make(map[string]int)
+	for _, rule := range ing.Spec.Rules {
+		host := rule.Host
+		if host == "" {
+			host = "*"
+		}
+		idx, ok := hostIndex[host]
+		if !ok {
+			idx = len(tree.Hosts)
+			hostIndex[host] = idx
+			tree.Hosts = append(tree.Hosts, models.IngressRouteHost{
+				Host:      host,
+				TLSSecret: ingressTLSSecret(ing, rule.Host),
+				Paths:     []models.IngressRoutePath{},
+			})
+		}
+		if rule.HTTP == nil {
+			continue
+		}
+		for _, p := range rule.HTTP.Paths {
+			pathType := ""
+			if p.PathType != nil {
+				pathType = string(*p.PathType)
+			}
+			backend := p.Backend
+			tree.Hosts[idx].Paths = append(tree.Hosts[idx].Paths, routePath(p.Path, pathType, &backend))
+		}
+	}
+
+	if ing.Spec.DefaultBackend != nil {
+		route := routePath("/", "", ing.Spec.DefaultBackend)
+		tree.DefaultBackend = &route
+	}
+	return tree
+}
+
+// ingressTLSSecret returns the TLS secret covering a host; a TLS entry without hosts covers all of them
+func ingressTLSSecret(ing *networkingv1.Ingress, host string) string {
+	for _, t := range ing.Spec.TLS {
+		if len(t.Hosts) == 0 {
+			return t.SecretName
+		}
+		for _, h := range t.Hosts {
+			if h == host {
+				return t.SecretName
+			}
+		}
+	}
+	return ""
+}
+
+// servicePods returns the pods a service selects; services without a selector select none
+func servicePods(svc *corev1.Service, pods []corev1.Pod) []models.RoutePod {
+	result := []models.RoutePod{}
+	if len(svc.Spec.Selector) == 0 {
+		return result
+	}
+	selector := labels.SelectorFromSet(svc.Spec.Selector)
+	for i := range pods {
+		pod := &pods[i]
+		if pod.Namespace != svc.Namespace || !selector.Matches(labels.Set(pod.Labels)) {
+			continue
+		}
+		result = append(result, models.RoutePod{
+			Name:     pod.Name,
+			Ready:    podConditionTrue(pod, corev1.PodReady),
+			PodIP:    pod.Status.PodIP,
+			NodeName: pod.Spec.NodeName,
+		})
+	}
+	return result
+}
+
+// servicesByKey indexes services by namespace/name
+func servicesByKey(services []corev1.Service) map[string]*corev1.Service {
+	result := make(map[string]*corev1.Service, len(services))
+	for i := range services {
+		result[services[i].Namespace+"/"+services[i].Name] = &services[i]
+	}
+	return result
+}
+
+// ingressBackend describes a backend, resolving it against services when they were looked up
+func ingressBackend(namespace string, b *networkingv1.IngressBackend, services map[string]*corev1.Service) models.IngressBackend {
+	backend := models.IngressBackend{}
+	switch {
+	case b.Service != nil:
+		backend.ServiceName = b.Service.Name
+		if b.Service.Port.Name != "" {
+			backend.ServicePort = b.Service.Port.Name
+		} else {
+			backend.ServicePort = strconv.Itoa(int(b.Service.Port.Number))
+		}
+		if services != nil {
+			if svc, ok := services[namespace+"/"+b.Service.Name]; ok {
+				resolved := toService(svc)
+				backend.Service = &resolved
+			} else {
+				backend.Missing = true
+			}
+		}
+	case b.Resource != nil:
+		backend.Resource = b.Resource.Kind + "/" + b.Resource.Name
+	}
+	return backend
+}
+
+// toIngress converts an ingress into the simplified view; services may be nil to skip resolution
+func toIngress(ing *networkingv1.Ingress, services map[string]*corev1.Service) models.Ingress {
+	ingress := models.Ingress{
+		Name:      ing.Name,
+		Namespace: ing.Namespace,
+		Hosts:     []string{},
+		Rules:     make([]models.IngressRule, 0, len(ing.Spec.Rules)),
+		Addresses: []string{},
+		CreatedAt: ing.CreationTimestamp.Time.Format(time.RFC3339),
+		Labels:    ing.Labels,
+	}
+	if ing.Spec.IngressClassName != nil {
+		ingress.IngressClassName = *ing.Spec.IngressClassName
+	}
+
+	seenHosts := make(map[string]bool)
+	for _, rule := range ing.Spec.Rules {
+		if rule.Host != "" && !seenHosts[rule.Host] {
+			seenHosts[rule.Host] = true
+			ingress.Hosts = append(ingress.Hosts, rule.Host)
+		}
+
+		converted := models.IngressRule{Host: rule.Host, Paths: []models.IngressPath{}}
+		if rule.HTTP != nil {
+			for _, p := range rule.HTTP.Paths {
+				path := models.IngressPath{Path: p.Path}
+				if p.PathType != nil {
+					path.PathType = string(*p.PathType)
+				}
+				backend := p.Backend
+				path.Backend = ingressBackend(ing.Namespace, &backend, services)
+				converted.Paths = append(converted.Paths, path)
+			}
+		}
+		ingress.Rules = append(ingress.Rules, converted)
+	}
+
+	if ing.Spec.DefaultBackend != nil {
+		backend := ingressBackend(ing.Namespace, ing.Spec.DefaultBackend, services)
+		ingress.DefaultBackend = &backend
+	}
+
+	for _, t := range ing.Spec.TLS {
+		ingress.TLS = append(ingress.TLS, models.IngressTLS{Hosts: t.Hosts, SecretName: t.SecretName})
+	}
+
+	for _, lb := range ing.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			ingress.Addresses = append(ingress.Addresses, lb.IP)
+		}
+		if lb.Hostname != "" {
+			ingress.Addresses = append(ingress.Addresses, lb.Hostname)
+		}
+	}
+
+	return ingress
+}
+
+// toIngressClass converts an ingress class into the simplified view
+func toIngressClass(c *networkingv1.IngressClass) models.IngressClass {
+	return models.IngressClass{
+		Name:       c.Name,
+		Controller: c.Spec.Controller,
+		IsDefault:  c.Annotations[defaultIngressClassAnnotation] == "true",
+		CreatedAt:  c.CreationTimestamp.Time.Format(time.RFC3339),
+	}
+}
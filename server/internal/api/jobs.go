@@ -0,0 +1,424 @@
+package api
+
+import (
+	"encoding/json"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	batchv1 "k8s.io/api/batch/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+// instantiateAnnotation marks jobs created from a cronjob outside its schedule, as kubectl create job --from does
+const instantiateAnnotation = "cronjob.kubernetes.io/instantiate"
+
+// ListJobs returns all jobs
+func ListJobs(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		jobs, err := clientset.BatchV1().Jobs("").List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListJobs, err)
+			http.Error(w, utils.MsgFailedListJobs, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.JobListResponse{Items: make([]models.Job, 0, len(jobs.Items))}
+		for _, j := range jobs.Items {
+			response.Items = append(response.Items, toJob(&j))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeJobsList, err)
+		}
+	}
+}
+
+// GetJob returns job details
+func GetJob(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		job, err := clientset.BatchV1().Jobs(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetJob, err)
+			http.Error(w, utils.MsgJobNotFound, http.StatusNotFound)
+			return
+		}
+
+		response := toJob(job)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeJob, err)
+		}
+	}
+}
+
+// DeleteJob deletes a job together with its pods unless another propagationPolicy is requested
+func DeleteJob(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		opts, err := parseDeleteOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		// The API defaults to orphaning the pods of a job, which kubectl avoids
+		if opts.PropagationPolicy == nil {
+			background := metav1.DeletePropagationBackground
+			opts.PropagationPolicy = &background
+		}
+
+		err = clientset.BatchV1().Jobs(namespace).Delete(r.Context(), name, opts)
+		if err != nil {
+			log.Printf(utils.LogFailedDeleteJob, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgJobNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedDeleteJob, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// ListCronJobs returns all cronjobs
+func ListCronJobs(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cronJobs, err := clientset.BatchV1().CronJobs("").List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListCronJobs, err)
+			http.Error(w, utils.MsgFailedListCronJobs, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.CronJobListResponse{Items: make([]models.CronJob, 0, len(cronJobs.Items))}
+		for _, c := range cronJobs.Items {
+			response.Items = append(response.Items, toCronJob(&c))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeCronJobsList, err)
+		}
+	}
+}
+
+// GetCronJob returns cronjob details
+func GetCronJob(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		cronJob, err := clientset.BatchV1().CronJobs(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetCronJob, err)
+			http.Error(w, utils.MsgCronJobNotFound, http.StatusNotFound)
+			return
+		}
+
+		response := toCronJob(cronJob)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeCronJob, err)
+		}
+	}
+}
+
+// DeleteCronJob deletes a cronjob and the jobs it owns unless another propagationPolicy is requested
+func DeleteCronJob(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		opts, err := parseDeleteOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		if opts.PropagationPolicy == nil {
+			background := metav1.DeletePropagationBackground
+			opts.PropagationPolicy = &background
+		}
+
+		err = clientset.BatchV1().CronJobs(namespace).Delete(r.Context(), name, opts)
+		if err != nil {
+			log.Printf(utils.LogFailedDeleteCronJob, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgCronJobNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedDeleteCronJob, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// SuspendCronJob stops a cronjob from scheduling new jobs
+func SuspendCronJob(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return setCronJobSuspended(clientset, true)
+}
+
+// ResumeCronJob lets a suspended cronjob schedule jobs again
+func ResumeCronJob(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return setCronJobSuspended(clientset, false)
+}
+
+func setCronJobSuspended(clientset *kubernetes.Clientset, suspend bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		data, err := json.Marshal(map[string]interface{}{
+			"spec": map[string]interface{}{"suspend": suspend},
+		})
+		if err != nil {
+			log.Printf(utils.LogFailedPatchCronJob, name, err)
+			http.Error(w, utils.MsgFailedUpdateCronJob, http.StatusInternalServerError)
+			return
+		}
+
+		patched, err := clientset.BatchV1().CronJobs(namespace).Patch(r.Context(), name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedPatchCronJob, name, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgCronJobNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedUpdateCronJob, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toCronJob(patched)); err != nil {
+			log.Printf(utils.LogFailedEncodeCronJob, err)
+		}
+	}
+}
+
+// TriggerCronJob creates a job from the cronjob's job template, like kubectl create job --from=cronjob/<name>
+func TriggerCronJob(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		var req models.TriggerCronJobRequest
+		if err := decodeOptionalBody(r, &req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		jobName := req.JobName
+		if jobName == "" {
+			jobName = manualJobName(name, time.Now())
+		}
+		// Job names end up in the job-name pod label, so they must be valid labels
+		var errs field.ErrorList
+		for _, msg := range validation.IsDNS1123Label(jobName) {
+			errs = append(errs, field.Invalid(field.NewPath("jobName"), jobName, msg))
+		}
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		cronJob, err := clientset.BatchV1().CronJobs(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetCronJob, err)
+			http.Error(w, utils.MsgCronJobNotFound, http.StatusNotFound)
+			return
+		}
+
+		created, err := clientset.BatchV1().Jobs(namespace).Create(r.Context(), jobFromCronJob(cronJob, jobName), metav1.CreateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedTriggerCronJob, name, err)
+			if apierrors.IsAlreadyExists(err) {
+				http.Error(w, utils.MsgJobAlreadyExists, http.StatusConflict)
+				return
+			}
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			http.Error(w, utils.MsgFailedTriggerCronJob, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(toJob(created)); err != nil {
+			log.Printf(utils.LogFailedEncodeTriggeredJob, err)
+		}
+	}
+}
+
+// jobFromCronJob copies the job template the same way kubectl create job --from does
+func jobFromCronJob(cronJob *batchv1.CronJob, name string) *batchv1.Job {
+	annotations := map[string]string{instantiateAnnotation: "manual"}
+	for k, v := range cronJob.Spec.JobTemplate.Annotations {
+		annotations[k] = v
+	}
+
+	return &batchv1.Job{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   cronJob.Namespace,
+			Labels:      cronJob.Spec.JobTemplate.Labels,
+			Annotations: annotations,
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(cronJob, batchv1.SchemeGroupVersion.WithKind("CronJob")),
+			},
+		},
+		Spec: cronJob.Spec.JobTemplate.Spec,
+	}
+}
+
+// manualJobName builds a default name that still fits in a label value
+func manualJobName(cronJob string, now time.Time) string {
+	suffix := "-manual-" + strconv.FormatInt(now.Unix(), 10)
+	if limit := validation.DNS1123LabelMaxLength - len(suffix); len(cronJob) > limit {
+		cronJob = strings.TrimRight(cronJob[:limit], "-.")
+	}
+	return cronJob + suffix
+}
+
+// jobStatus summarises the job conditions into a single word
+func jobStatus(j *batchv1.Job) string {
+	for _, c := range j.Status.Conditions {
+		if c.Status != corev1.ConditionTrue {
+			continue
+		}
+		switch c.Type {
+		case batchv1.JobComplete:
+			return "Complete"
+		case batchv1.JobFailed:
+			return "Failed"
+		}
+	}
+	switch {
+	case j.Spec.Suspend != nil && *j.Spec.Suspend:
+		return "Suspended"
+	case j.Status.Active > 0:
+		return "Running"
+	default:
+		return "Pending"
+	}
+}
+
+// jobFailedTime returns when a job's Failed condition became true, or nil if it has not failed
+func jobFailedTime(j *batchv1.Job) *metav1.Time {
+	for i := range j.Status.Conditions {
+		c := &j.Status.Conditions[i]
+		if c.Type == batchv1.JobFailed && c.Status == corev1.ConditionTrue && !c.LastTransitionTime.IsZero() {
+			return &c.LastTransitionTime
+		}
+	}
+	return nil
+}
+
+// toJob converts a job into the simplified view
+func toJob(j *batchv1.Job) models.Job {
+	images := make([]string, 0, len(j.Spec.Template.Spec.Containers))
+	for _, c := range j.Spec.Template.Spec.Containers {
+		images = append(images, c.Image)
+	}
+
+	job := models.Job{
+		Name:         j.Name,
+		Namespace:    j.Namespace,
+		Status:       jobStatus(j),
+		Completions:  j.Spec.Completions,
+		Parallelism:  j.Spec.Parallelism,
+		BackoffLimit: j.Spec.BackoffLimit,
+		Active:       j.Status.Active,
+		Succeeded:    j.Status.Succeeded,
+		Failed:       j.Status.Failed,
+		Suspended:    j.Spec.Suspend != nil && *j.Spec.Suspend,
+		Images:       images,
+		CreatedAt:    j.CreationTimestamp.Time.Format(time.RFC3339),
+		Labels:       j.Labels,
+	}
+
+	if ref := metav1.GetControllerOf(j); ref != nil && ref.Kind == "CronJob" {
+		job.CronJob = ref.Name
+	}
+
+	if j.Status.StartTime != nil {
+		job.StartTime = formatTime(*j.Status.StartTime)
+		end := time.Now()
+		if j.Status.CompletionTime != nil {
+			job.CompletionTime = formatTime(*j.Status.CompletionTime)
+			end = j.Status.CompletionTime.Time
+		} else if failedAt := jobFailedTime(j); failedAt != nil {
+			// Failed jobs never get a completionTime; they end when the Failed condition was set
+			end = failedAt.Time
+		}
+		duration := end.Sub(j.Status.StartTime.Time).Round(time.Second)
+		job.Duration = duration.String()
+		job.DurationSeconds = int64(duration.Seconds())
+	}
+
+	return job
+}
+
+// toCronJob converts a cronjob into the simplified view
+func toCronJob(c *batchv1.CronJob) models.CronJob {
+	images := make([]string, 0, len(c.Spec.JobTemplate.Spec.Template.Spec.Containers))
+	for _, container := range c.Spec.JobTemplate.Spec.Template.Spec.Containers {
+		images = append(images, container.Image)
+	}
+
+	active := make([]string, 0, len(c.Status.Active))
+	for _, ref := range c.Status.Active {
+		active = append(active, ref.Name)
+	}
+
+	cronJob := models.CronJob{
+		Name:              c.Name,
+		Namespace:         c.Namespace,
+		Schedule:          c.Spec.Schedule,
+		Suspended:         c.Spec.Suspend != nil && *c.Spec.Suspend,
+		ConcurrencyPolicy: string(c.Spec.ConcurrencyPolicy),
+		Active:            active,
+		Images:            images,
+		CreatedAt:         c.CreationTimestamp.Time.Format(time.RFC3339),
+		Labels:            c.Labels,
+	}
+	if c.Spec.TimeZone != nil {
+		cronJob.TimeZone = *c.Spec.TimeZone
+	}
+	if c.Status.LastScheduleTime != nil {
+		cronJob.LastScheduleTime = formatTime(*c.Status.LastScheduleTime)
+	}
+	if c.Status.LastSuccessfulTime != nil {
+		cronJob.LastSuccessfulTime = formatTime(*c.Status.LastSuccessfulTime)
+	}
+	return cronJob
+}
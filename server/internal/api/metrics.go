@@ -2,6 +2,9 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
+	"fmt"
+	"k8_gui/internal/metricsource"
 	"k8_gui/internal/models"
 	"k8_gui/internal/utils"
 	"log"
@@ -13,11 +16,10 @@ import (
 	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
-	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
 // GetNodeMetrics returns metrics for a specific node
-func GetNodeMetrics(clientset *kubernetes.Clientset, metricsClient metricsclientset.Interface) http.HandlerFunc {
+func GetNodeMetrics(clientset *kubernetes.Clientset, provider metricsource.Provider) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		nodeName := vars["name"]
@@ -30,51 +32,23 @@ func GetNodeMetrics(clientset *kubernetes.Clientset, metricsClient metricsclient
 			return
 		}
 
+		// Committed requests are best effort; usage is still useful without them
+		podsByNode, err := scheduledPodsByNode(r.Context(), clientset, nodeName)
+		if err != nil {
+			log.Printf(utils.LogFailedListNodePods, err)
+		}
+
 		// Get node metrics
-		metrics, err := metricsClient.MetricsV1beta1().NodeMetricses().Get(r.Context(), nodeName, metav1.GetOptions{})
+		usage, err := provider.NodeUsage(r.Context(), nodeName)
 		if err != nil {
-			log.Printf(utils.LogFailedGetNodeMetricsAPI, nodeName, err)
-			response := models.NodeMetrics{
-				NodeName:  nodeName,
-				Available: false,
-				Message:   "Metrics not available",
-				Timestamp: time.Now(),
-			}
-			w.Header().Set("Content-Type", "application/json")
-			if err := json.NewEncoder(w).Encode(response); err != nil {
-				log.Printf(utils.LogFailedEncodeNodeMetricsError, err)
+			if !errors.Is(err, metricsource.ErrNoData) {
+				log.Printf(utils.LogFailedGetNodeMetricsAPI, nodeName, err)
 			}
-			return
+			usage = nil
 		}
 
-		// Calculate CPU metrics
-		cpuUsage := metrics.Usage[corev1.ResourceCPU]
-		cpuCapacity := node.Status.Capacity[corev1.ResourceCPU]
-		cpuPercentage := float64(cpuUsage.MilliValue()) / float64(cpuCapacity.MilliValue()) * 100
-
-		// Calculate Memory metrics
-		memoryUsage := metrics.Usage[corev1.ResourceMemory]
-		memoryCapacity := node.Status.Capacity[corev1.ResourceMemory]
-		memoryPercentage := float64(memoryUsage.Value()) / float64(memoryCapacity.Value()) * 100
-
-		response := models.NodeMetrics{
-			NodeName: nodeName,
-			CPU: models.NodeResourceMetric{
-				Value:      cpuUsage.String(),
-				Quantity:   cpuUsage.MilliValue(),
-				Percentage: cpuPercentage,
-				Unit:       "m",
-			},
-			Memory: models.NodeResourceMetric{
-				Value:      memoryUsage.String(),
-				Quantity:   memoryUsage.Value(),
-				Percentage: memoryPercentage,
-				Unit:       "bytes",
-			},
-			Timestamp: metrics.Timestamp.Time,
-			Window:    metrics.Window.Duration.String(),
-			Available: true,
-		}
+		response := nodeMetrics(node, usage, podsByNode[nodeName], podsByNode != nil)
+		response.Source = provider.Name()
 
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
@@ -83,37 +57,46 @@ func GetNodeMetrics(clientset *kubernetes.Clientset, metricsClient metricsclient
 	}
 }
 
-// GetNodesMetrics returns metrics for all nodes
-func GetNodesMetrics(metricsClient metricsclientset.Interface) http.HandlerFunc {
+// GetNodesMetrics returns metrics for all nodes, including nodes the metrics provider has no data for
+func GetNodesMetrics(clientset *kubernetes.Clientset, provider metricsource.Provider) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		metrics, err := metricsClient.MetricsV1beta1().NodeMetricses().List(r.Context(), metav1.ListOptions{})
+		nodes, err := clientset.CoreV1().Nodes().List(r.Context(), metav1.ListOptions{})
 		if err != nil {
-			log.Printf(utils.LogFailedGetNodeMetricsList, err)
-			http.Error(w, utils.MsgFailedGetNodeMetrics, http.StatusInternalServerError)
+			log.Printf(utils.LogFailedListNodes, err)
+			http.Error(w, utils.MsgFailedListNodes, http.StatusInternalServerError)
 			return
 		}
 
-		response := models.NodeMetricsListResponse{Items: make([]models.NodeMetrics, 0, len(metrics.Items))}
-		for _, m := range metrics.Items {
-			cpuUsage := m.Usage[corev1.ResourceCPU]
-			memoryUsage := m.Usage[corev1.ResourceMemory]
-
-			response.Items = append(response.Items, models.NodeMetrics{
-				NodeName: m.Name,
-				CPU: models.NodeResourceMetric{
-					Value:    (&cpuUsage).String(),
-					Quantity: (&cpuUsage).MilliValue(),
-					Unit:     "m",
-				},
-				Memory: models.NodeResourceMetric{
-					Value:    (&memoryUsage).String(),
-					Quantity: (&memoryUsage).Value(),
-					Unit:     "bytes",
-				},
-				Timestamp: m.Timestamp.Time,
-				Window:    m.Window.Duration.String(),
-				Available: true,
-			})
+		// Without usage every node is still listed, reported as missing
+		metrics, metricsErr := provider.NodesUsage(r.Context())
+		if metricsErr != nil {
+			log.Printf(utils.LogFailedGetNodeMetricsList, metricsErr)
+		}
+		usage := make(map[string]*metricsource.NodeUsage, len(metrics))
+		for i := range metrics {
+			usage[metrics[i].Name] = &metrics[i]
+		}
+
+		podsByNode, err := scheduledPodsByNode(r.Context(), clientset, "")
+		if err != nil {
+			log.Printf(utils.LogFailedListNodePods, err)
+		}
+
+		response := models.NodeMetricsListResponse{
+			Items:   make([]models.NodeMetrics, 0, len(nodes.Items)),
+			Missing: []string{},
+			Source:  provider.Name(),
+		}
+		if metricsErr != nil {
+			response.Message = fmt.Sprintf("%s: %v", utils.MsgFailedGetNodeMetrics, metricsErr)
+		}
+		for i := range nodes.Items {
+			node := &nodes.Items[i]
+			item := nodeMetrics(node, usage[node.Name], podsByNode[node.Name], podsByNode != nil)
+			if !item.Available {
+				response.Missing = append(response.Missing, node.Name)
+			}
+			response.Items = append(response.Items, item)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -123,60 +106,79 @@ func GetNodesMetrics(metricsClient metricsclientset.Interface) http.HandlerFunc
 	}
 }
 
+// nodeMetrics joins a node with its usage and the requests of its pods. usage is nil when the
+// provider has no data for the node; requests are only reported when withPods is set.
+func nodeMetrics(node *corev1.Node, usage *metricsource.NodeUsage, pods []corev1.Pod, withPods bool) models.NodeMetrics {
+	response := models.NodeMetrics{
+		NodeName: node.Name,
+		CPU:      models.NodeResourceMetric{Unit: "m"},
+		Memory:   models.NodeResourceMetric{Unit: "bytes"},
+	}
+
+	if usage != nil {
+		response.CPU = nodeResourceMetric(*resource.NewMilliQuantity(usage.Usage.CPU, resource.DecimalSI), node, corev1.ResourceCPU)
+		response.Memory = nodeResourceMetric(*resource.NewQuantity(usage.Usage.Memory, resource.BinarySI), node, corev1.ResourceMemory)
+		response.Network = networkMetric(usage.Usage)
+		response.Filesystem = filesystemMetric(usage.Usage)
+		if response.Filesystem != nil {
+			storage := node.Status.Capacity[corev1.ResourceEphemeralStorage]
+			response.Filesystem.CapacityPercentage = quantityPercentage(*resource.NewQuantity(*usage.Usage.Filesystem, resource.BinarySI), storage, false)
+		}
+		response.Timestamp = usage.Timestamp
+		response.Window = usage.Window.String()
+		response.Available = true
+	} else {
+		response.Message = "Metrics not available"
+		response.Timestamp = time.Now()
+	}
+
+	if withPods {
+		requests := corev1.ResourceList{}
+		for i := range pods {
+			podRequests, _ := podRequestsAndLimits(&pods[i])
+			addResourceList(requests, podRequests)
+		}
+		cpuRequests := requests[corev1.ResourceCPU]
+		memoryRequests := requests[corev1.ResourceMemory]
+		response.CPU.Requests = cpuRequests.String()
+		response.CPU.RequestsPercentage = quantityPercentage(cpuRequests, node.Status.Allocatable[corev1.ResourceCPU], true)
+		response.Memory.Requests = memoryRequests.String()
+		response.Memory.RequestsPercentage = quantityPercentage(memoryRequests, node.Status.Allocatable[corev1.ResourceMemory], false)
+	}
+
+	return response
+}
+
+// nodeResourceMetric reports usage of a node resource against its allocatable and capacity
+func nodeResourceMetric(usage resource.Quantity, node *corev1.Node, name corev1.ResourceName) models.NodeResourceMetric {
+	milli := name == corev1.ResourceCPU
+	metric := models.NodeResourceMetric{
+		Value:              usage.String(),
+		Quantity:           usage.Value(),
+		Percentage:         quantityPercentage(usage, node.Status.Allocatable[name], milli),
+		CapacityPercentage: quantityPercentage(usage, node.Status.Capacity[name], milli),
+		Unit:               "bytes",
+	}
+	if milli {
+		metric.Quantity = usage.MilliValue()
+		metric.Unit = "m"
+	}
+	return metric
+}
+
 // GetPodsMetrics returns metrics for all pods
-func GetPodsMetrics(metricsClient metricsclientset.Interface) http.HandlerFunc {
+func GetPodsMetrics(provider metricsource.Provider) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		metrics, err := metricsClient.MetricsV1beta1().PodMetricses("").List(r.Context(), metav1.ListOptions{})
+		metrics, err := provider.PodsUsage(r.Context(), "")
 		if err != nil {
 			log.Printf(utils.LogFailedGetPodMetrics, err)
 			http.Error(w, utils.MsgFailedGetPodMetrics, http.StatusInternalServerError)
 			return
 		}
 
-		response := models.PodMetricsListResponse{Items: make([]models.PodMetrics, 0, len(metrics.Items))}
-		for _, m := range metrics.Items {
-			containers := make([]models.ContainerMetrics, len(m.Containers))
-			totalCPU := resource.NewQuantity(0, resource.DecimalSI)
-			totalMemory := resource.NewQuantity(0, resource.BinarySI)
-
-			for i, c := range m.Containers {
-				cpu := c.Usage[corev1.ResourceCPU]
-				memory := c.Usage[corev1.ResourceMemory]
-				totalCPU.Add(cpu)
-				totalMemory.Add(memory)
-
-				containers[i] = models.ContainerMetrics{
-					Name: c.Name,
-					CPU: models.NodeResourceMetric{
-						Value:    cpu.String(),
-						Quantity: cpu.MilliValue(),
-						Unit:     "m",
-					},
-					Memory: models.NodeResourceMetric{
-						Value:    memory.String(),
-						Quantity: memory.Value(),
-						Unit:     "bytes",
-					},
-				}
-			}
-
-			response.Items = append(response.Items, models.PodMetrics{
-				PodName:    m.Name,
-				Namespace:  m.Namespace,
-				Containers: containers,
-				CPU: models.NodeResourceMetric{
-					Value:    totalCPU.String(),
-					Quantity: totalCPU.MilliValue(),
-					Unit:     "m",
-				},
-				Memory: models.NodeResourceMetric{
-					Value:    totalMemory.String(),
-					Quantity: totalMemory.Value(),
-					Unit:     "bytes",
-				},
-				Timestamp: m.Timestamp.Time,
-				Window:    m.Window.Duration.String(),
-			})
+		response := models.PodMetricsListResponse{Items: make([]models.PodMetrics, 0, len(metrics)), Source: provider.Name()}
+		for _, m := range metrics {
+			response.Items = append(response.Items, toPodMetrics(m))
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -187,62 +189,21 @@ func GetPodsMetrics(metricsClient metricsclientset.Interface) http.HandlerFunc {
 }
 
 // GetPodMetricsByNamespace returns metrics for pods in a specific namespace
-func GetPodMetricsByNamespace(metricsClient metricsclientset.Interface) http.HandlerFunc {
+func GetPodMetricsByNamespace(provider metricsource.Provider) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		namespace := vars["namespace"]
 
-		metrics, err := metricsClient.MetricsV1beta1().PodMetricses(namespace).List(r.Context(), metav1.ListOptions{})
+		metrics, err := provider.PodsUsage(r.Context(), namespace)
 		if err != nil {
 			log.Printf(utils.LogFailedGetPodMetricsNamespace, namespace, err)
 			http.Error(w, utils.MsgFailedGetPodMetrics, http.StatusInternalServerError)
 			return
 		}
 
-		response := models.PodMetricsListResponse{Items: make([]models.PodMetrics, 0, len(metrics.Items))}
-		for _, m := range metrics.Items {
-			containers := make([]models.ContainerMetrics, len(m.Containers))
-			totalCPU := resource.NewQuantity(0, resource.DecimalSI)
-			totalMemory := resource.NewQuantity(0, resource.BinarySI)
-
-			for i, c := range m.Containers {
-				cpu := c.Usage[corev1.ResourceCPU]
-				memory := c.Usage[corev1.ResourceMemory]
-				totalCPU.Add(cpu)
-				totalMemory.Add(memory)
-
-				containers[i] = models.ContainerMetrics{
-					Name: c.Name,
-					CPU: models.NodeResourceMetric{
-						Value:    cpu.String(),
-						Quantity: cpu.MilliValue(),
-						Unit:     "m",
-					},
-					Memory: models.NodeResourceMetric{
-						Value:    memory.String(),
-						Quantity: memory.Value(),
-						Unit:     "bytes",
-					},
-				}
-			}
-
-			response.Items = append(response.Items, models.PodMetrics{
-				PodName:    m.Name,
-				Namespace:  m.Namespace,
-				Containers: containers,
-				CPU: models.NodeResourceMetric{
-					Value:    totalCPU.String(),
-					Quantity: totalCPU.MilliValue(),
-					Unit:     "m",
-				},
-				Memory: models.NodeResourceMetric{
-					Value:    totalMemory.String(),
-					Quantity: totalMemory.Value(),
-					Unit:     "bytes",
-				},
-				Timestamp: m.Timestamp.Time,
-				Window:    m.Window.Duration.String(),
-			})
+		response := models.PodMetricsListResponse{Items: make([]models.PodMetrics, 0, len(metrics)), Source: provider.Name()}
+		for _, m := range metrics {
+			response.Items = append(response.Items, toPodMetrics(m))
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -251,3 +212,70 @@ func GetPodMetricsByNamespace(metricsClient metricsclientset.Interface) http.Han
 		}
 	}
 }
+
+// toPodMetrics converts provider pod usage into the API view
+func toPodMetrics(m metricsource.PodUsage) models.PodMetrics {
+	containers := make([]models.ContainerMetrics, len(m.Containers))
+	for i, c := range m.Containers {
+		containers[i] = models.ContainerMetrics{
+			Name:       c.Name,
+			CPU:        cpuMetric(c.Usage.CPU),
+			Memory:     memoryMetric(c.Usage.Memory),
+			Filesystem: filesystemMetric(c.Usage),
+		}
+	}
+
+	return models.PodMetrics{
+		PodName:    m.Name,
+		Namespace:  m.Namespace,
+		Containers: containers,
+		CPU:        cpuMetric(m.Usage.CPU),
+		Memory:     memoryMetric(m.Usage.Memory),
+		Network:    networkMetric(m.Usage),
+		Filesystem: filesystemMetric(m.Usage),
+		Timestamp:  m.Timestamp,
+		Window:     m.Window.String(),
+	}
+}
+
+// cpuMetric describes a CPU usage in millicores
+func cpuMetric(millicores int64) models.NodeResourceMetric {
+	return models.NodeResourceMetric{
+		Value:    resource.NewMilliQuantity(millicores, resource.DecimalSI).String(),
+		Quantity: millicores,
+		Unit:     "m",
+	}
+}
+
+// memoryMetric describes a memory usage in bytes
+func memoryMetric(bytes int64) models.NodeResourceMetric {
+	return models.NodeResourceMetric{
+		Value:    resource.NewQuantity(bytes, resource.BinarySI).String(),
+		Quantity: bytes,
+		Unit:     "bytes",
+	}
+}
+
+// networkMetric returns network throughput, or nil when the provider does not collect it
+func networkMetric(usage metricsource.Usage) *models.NetworkMetric {
+	if usage.NetworkReceive == nil && usage.NetworkTransmit == nil {
+		return nil
+	}
+	network := &models.NetworkMetric{}
+	if usage.NetworkReceive != nil {
+		network.ReceiveBytesPerSecond = *usage.NetworkReceive
+	}
+	if usage.NetworkTransmit != nil {
+		network.TransmitBytesPerSecond = *usage.NetworkTransmit
+	}
+	return network
+}
+
+// filesystemMetric returns filesystem usage, or nil when the provider does not collect it
+func filesystemMetric(usage metricsource.Usage) *models.NodeResourceMetric {
+	if usage.Filesystem == nil {
+		return nil
+	}
+	metric := memoryMetric(*usage.Filesystem)
+	return &metric
+}
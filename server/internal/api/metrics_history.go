@@ -0,0 +1,165 @@
+package api
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"k8_gui/internal/metricsource"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+)
+
+const (
+	defaultHistoryRange = time.Hour
+	// maxHistoryPoints bounds the points a single query can ask for, like Prometheus does
+	maxHistoryPoints = 11000
+)
+
+// GetNodeMetricsHistory returns the recorded usage of a node
+func GetNodeMetricsHistory(provider metricsource.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := mux.Vars(r)["name"]
+		writeMetricsHistory(w, r, provider, metricsource.Target{Kind: metricsource.KindNode, Name: name})
+	}
+}
+
+// GetPodMetricsHistory returns the recorded usage of a pod
+func GetPodMetricsHistory(provider metricsource.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+		writeMetricsHistory(w, r, provider, metricsource.Target{Kind: metricsource.KindPod, Namespace: namespace, Name: name})
+	}
+}
+
+// GetNamespaceMetricsHistory returns the recorded usage of all pods in a namespace
+func GetNamespaceMetricsHistory(provider metricsource.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		namespace := mux.Vars(r)["namespace"]
+		writeMetricsHistory(w, r, provider, metricsource.Target{Kind: metricsource.KindNamespace, Namespace: namespace})
+	}
+}
+
+// GetDeploymentMetricsHistory returns the recorded usage of all pods of a deployment
+func GetDeploymentMetricsHistory(provider metricsource.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+		writeMetricsHistory(w, r, provider, metricsource.Target{Kind: metricsource.KindDeployment, Namespace: namespace, Name: name})
+	}
+}
+
+// writeMetricsHistory queries the provider for the range in the request and writes it as JSON
+func writeMetricsHistory(w http.ResponseWriter, r *http.Request, provider metricsource.Provider, target metricsource.Target) {
+	from, to, step, err := parseHistoryRange(r)
+	if err != nil {
+		http.Error(w, fmt.Sprintf("%s: %s", utils.MsgInvalidHistoryRange, err), http.StatusBadRequest)
+		return
+	}
+
+	points, err := provider.Range(r.Context(), target, from, to, step)
+	if err != nil {
+		log.Printf(utils.LogFailedQueryMetricsHistory, target.Kind, target.Namespace, target.Name, err)
+		if errors.Is(err, metricsource.ErrRangeUnsupported) {
+			http.Error(w, utils.MsgMetricsHistoryUnsupported, http.StatusNotImplemented)
+			return
+		}
+		http.Error(w, utils.MsgFailedGetMetricsHistory, http.StatusBadGateway)
+		return
+	}
+
+	response := models.MetricsHistory{
+		Kind:      target.Kind,
+		Namespace: target.Namespace,
+		Name:      target.Name,
+		From:      from,
+		To:        to,
+		Source:    provider.Name(),
+		Points:    make([]models.MetricsPoint, 0, len(points)),
+	}
+	if step > 0 {
+		response.Step = step.String()
+	}
+	for _, p := range points {
+		response.Points = append(response.Points, models.MetricsPoint{
+			Timestamp:       p.Time,
+			CPU:             p.Usage.CPU,
+			Memory:          p.Usage.Memory,
+			NetworkReceive:  p.Usage.NetworkReceive,
+			NetworkTransmit: p.Usage.NetworkTransmit,
+			Filesystem:      p.Usage.Filesystem,
+		})
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf(utils.LogFailedEncodeMetricsHistory, err)
+	}
+}
+
+// parseHistoryRange reads from, to and step from the query. from and to are RFC3339 or unix
+// seconds and default to the last hour; step is a duration such as 5m or a number of seconds,
+// and zero returns samples at their stored resolution.
+func parseHistoryRange(r *http.Request) (time.Time, time.Time, time.Duration, error) {
+	query := r.URL.Query()
+
+	to := time.Now()
+	if v := query.Get("to"); v != "" {
+		parsed, err := parseHistoryTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("to: %w", err)
+		}
+		to = parsed
+	}
+
+	from := to.Add(-defaultHistoryRange)
+	if v := query.Get("from"); v != "" {
+		parsed, err := parseHistoryTime(v)
+		if err != nil {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("from: %w", err)
+		}
+		from = parsed
+	}
+	if !from.Before(to) {
+		return time.Time{}, time.Time{}, 0, errors.New("from must be before to")
+	}
+
+	var step time.Duration
+	if v := query.Get("step"); v != "" {
+		if seconds, err := strconv.ParseInt(v, 10, 64); err == nil {
+			step = time.Duration(seconds) * time.Second
+		} else if parsed, err := time.ParseDuration(v); err == nil {
+			step = parsed
+		} else {
+			return time.Time{}, time.Time{}, 0, errors.New("step must be a duration such as 30s or a number of seconds")
+		}
+		if step < 0 {
+			return time.Time{}, time.Time{}, 0, errors.New("step must not be negative")
+		}
+		if step > 0 && to.Sub(from)/step > maxHistoryPoints {
+			return time.Time{}, time.Time{}, 0, fmt.Errorf("range and step exceed %d points", maxHistoryPoints)
+		}
+	}
+
+	return from, to, step, nil
+}
+
+// parseHistoryTime parses an RFC3339 timestamp or unix seconds
+func parseHistoryTime(value string) (time.Time, error) {
+	if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+		return time.Unix(seconds, 0), nil
+	}
+	parsed, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, errors.New("must be RFC3339 or unix seconds")
+	}
+	return parsed, nil
+}
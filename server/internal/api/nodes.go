@@ -1,18 +1,36 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
+	"fmt"
+	"k8_gui/internal/metricsource"
 	"k8_gui/internal/models"
 	"k8_gui/internal/utils"
 	"log"
 	"net/http"
+	"sort"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
 )
 
+// Labels kubectl uses to show node roles
+const (
+	nodeRoleLabelPrefix = "node-role.kubernetes.io/"
+	nodeRoleLabel       = "kubernetes.io/role"
+)
+
 // ListNodes returns all nodes
 func ListNodes(clientset *kubernetes.Clientset) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -23,28 +41,15 @@ func ListNodes(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
-		response := models.NodeListResponse{Items: make([]models.Node, 0, len(nodes.Items))}
-		for _, n := range nodes.Items {
-			capacity := make(map[string]string)
-			allocatable := make(map[string]string)
-
-			for resourceName, quantity := range n.Status.Capacity {
-				capacity[string(resourceName)] = quantity.String()
-			}
-			for resourceName, quantity := range n.Status.Allocatable {
-				allocatable[string(resourceName)] = quantity.String()
-			}
+		// Allocation is best effort; the node list is still useful without it
+		podsByNode, err := scheduledPodsByNode(r.Context(), clientset, "")
+		if err != nil {
+			log.Printf(utils.LogFailedListNodePods, err)
+		}
 
-			response.Items = append(response.Items, models.Node{
-				Name:        n.Name,
-				Status:      string(n.Status.Phase),
-				Version:     n.Status.NodeInfo.KubeletVersion,
-				OSImage:     n.Status.NodeInfo.OSImage,
-				Capacity:    capacity,
-				Allocatable: allocatable,
-				CreatedAt:   n.CreationTimestamp.Time.Format(time.RFC3339),
-				Labels:      n.Labels,
-			})
+		response := models.NodeListResponse{Items: make([]models.Node, 0, len(nodes.Items))}
+		for i := range nodes.Items {
+			response.Items = append(response.Items, toNode(&nodes.Items[i], podsByNode[nodes.Items[i].Name], podsByNode != nil))
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -67,30 +72,538 @@ func GetNode(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
-		capacity := make(map[string]string)
-		allocatable := make(map[string]string)
+		podsByNode, err := scheduledPodsByNode(r.Context(), clientset, name)
+		if err != nil {
+			log.Printf(utils.LogFailedListNodePods, err)
+		}
+		response := toNode(node, podsByNode[name], podsByNode != nil)
 
-		for resourceName, quantity := range node.Status.Capacity {
-			capacity[string(resourceName)] = quantity.String()
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeNode, err)
 		}
-		for resourceName, quantity := range node.Status.Allocatable {
-			allocatable[string(resourceName)] = quantity.String()
+	}
+}
+
+// GetNodePods returns the pods scheduled on a node with their requests, limits and live usage.
+// provider may be nil, in which case usage is omitted.
+func GetNodePods(clientset *kubernetes.Clientset, provider metricsource.Provider) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name := vars["name"]
+
+		if _, err := clientset.CoreV1().Nodes().Get(r.Context(), name, metav1.GetOptions{}); err != nil {
+			log.Printf(utils.LogFailedGetNode, err)
+			http.Error(w, utils.MsgNodeNotFound, http.StatusNotFound)
+			return
 		}
 
-		response := models.Node{
-			Name:        node.Name,
-			Status:      string(node.Status.Phase),
-			Version:     node.Status.NodeInfo.KubeletVersion,
-			OSImage:     node.Status.NodeInfo.OSImage,
-			Capacity:    capacity,
-			Allocatable: allocatable,
-			CreatedAt:   node.CreationTimestamp.Time.Format(time.RFC3339),
-			Labels:      node.Labels,
+		pods, err := clientset.CoreV1().Pods("").List(r.Context(), metav1.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("spec.nodeName", name).String(),
+		})
+		if err != nil {
+			log.Printf(utils.LogFailedListPodsOnNode, name, err)
+			http.Error(w, utils.MsgFailedListPodsOnNode, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.NodePodListResponse{Node: name, Items: make([]models.NodePod, 0, len(pods.Items))}
+
+		// Providers cannot filter pod usage by node, so usage is looked up by namespace/name
+		usage := make(map[string]metricsource.Usage)
+		if provider == nil {
+			response.Message = "Metrics not available"
+		} else if metrics, err := provider.PodsUsage(r.Context(), ""); err != nil {
+			log.Printf(utils.LogFailedGetNodePodMetrics, name, err)
+			response.Message = "Metrics not available"
+		} else {
+			response.MetricsAvailable = true
+			for _, m := range metrics {
+				usage[m.Namespace+"/"+m.Name] = m.Usage
+			}
 		}
 
+		for i := range pods.Items {
+			pod := &pods.Items[i]
+			requests, limits := podRequestsAndLimits(pod)
+			item := models.NodePod{
+				Pod:      toPod(pod),
+				Requests: resourceListToMap(requests),
+				Limits:   resourceListToMap(limits),
+			}
+			if total, ok := usage[pod.Namespace+"/"+pod.Name]; ok {
+				cpu := cpuMetric(total.CPU)
+				memory := memoryMetric(total.Memory)
+				item.CPU = &cpu
+				item.Memory = &memory
+			}
+			response.Items = append(response.Items, item)
+		}
+		sort.SliceStable(response.Items, func(i, j int) bool {
+			return nodePodCPU(response.Items[i]) > nodePodCPU(response.Items[j])
+		})
+
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeNodePods, err)
+		}
+	}
+}
+
+// nodePodCPU returns the CPU usage of a pod in millicores, or -1 without metrics so those sort last
+func nodePodCPU(pod models.NodePod) int64 {
+	if pod.CPU == nil {
+		return -1
+	}
+	return pod.CPU.Quantity
+}
+
+// CordonNode marks a node unschedulable
+func CordonNode(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return setNodeUnschedulable(clientset, true)
+}
+
+// UncordonNode marks a node schedulable again
+func UncordonNode(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return setNodeUnschedulable(clientset, false)
+}
+
+// setNodeUnschedulable returns a handler that patches spec.unschedulable of a node
+func setNodeUnschedulable(clientset *kubernetes.Clientset, unschedulable bool) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		name := vars["name"]
+
+		node, err := patchNodeUnschedulable(r.Context(), clientset, name, unschedulable)
+		if err != nil {
+			log.Printf(utils.LogFailedCordonNode, name, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgNodeNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedCordonNode, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toNode(node, nil, false)); err != nil {
 			log.Printf(utils.LogFailedEncodeNode, err)
 		}
 	}
 }
+
+// patchNodeUnschedulable sets spec.unschedulable, the same change kubectl cordon makes
+func patchNodeUnschedulable(ctx context.Context, clientset *kubernetes.Clientset, name string, unschedulable bool) (*corev1.Node, error) {
+	patch := []byte(fmt.Sprintf(`{"spec":{"unschedulable":%t}}`, unschedulable))
+	return clientset.CoreV1().Nodes().Patch(ctx, name, types.StrategicMergePatchType, patch, metav1.PatchOptions{})
+}
+
+// UpdateNodeTaints adds, modifies and removes taints on a node
+func UpdateNodeTaints(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.UpdateNodeTaintsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		updateNode(w, r, clientset, req.ResourceVersion, func(node *corev1.Node) field.ErrorList {
+			taints, errs := applyTaintChanges(node.Spec.Taints, req)
+			node.Spec.Taints = taints
+			return errs
+		})
+	}
+}
+
+// UpdateNodeLabels sets and removes labels on a node
+func UpdateNodeLabels(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.UpdateNodeLabelsRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		errs := validateLabels(req.Set, field.NewPath("set"))
+		for i, key := range req.Remove {
+			for _, msg := range validation.IsQualifiedName(key) {
+				errs = append(errs, field.Invalid(field.NewPath("remove").Index(i), key, msg))
+			}
+			if _, ok := req.Set[key]; ok {
+				errs = append(errs, field.Invalid(field.NewPath("remove").Index(i), key, "label cannot be both set and removed"))
+			}
+		}
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		updateNode(w, r, clientset, req.ResourceVersion, func(node *corev1.Node) field.ErrorList {
+			if node.Labels == nil {
+				node.Labels = make(map[string]string, len(req.Set))
+			}
+			for k, v := range req.Set {
+				node.Labels[k] = v
+			}
+			for _, key := range req.Remove {
+				delete(node.Labels, key)
+			}
+			return nil
+		})
+	}
+}
+
+// updateNode reads a node, applies mutate and writes it back, rejecting concurrent changes with 409
+func updateNode(w http.ResponseWriter, r *http.Request, clientset *kubernetes.Clientset, resourceVersion string, mutate func(node *corev1.Node) field.ErrorList) {
+	name := mux.Vars(r)["name"]
+
+	node, err := clientset.CoreV1().Nodes().Get(r.Context(), name, metav1.GetOptions{})
+	if err != nil {
+		log.Printf(utils.LogFailedGetNode, err)
+		http.Error(w, utils.MsgNodeNotFound, http.StatusNotFound)
+		return
+	}
+	if resourceVersion != "" && resourceVersion != node.ResourceVersion {
+		http.Error(w, utils.MsgNodeModified, http.StatusConflict)
+		return
+	}
+
+	if errs := mutate(node); len(errs) > 0 {
+		writeValidationErrors(w, fieldErrors(errs))
+		return
+	}
+
+	updated, err := clientset.CoreV1().Nodes().Update(r.Context(), node, metav1.UpdateOptions{})
+	if err != nil {
+		log.Printf(utils.LogFailedUpdateNode, name, err)
+		switch {
+		case apierrors.IsConflict(err):
+			http.Error(w, utils.MsgNodeModified, http.StatusConflict)
+		case apierrors.IsNotFound(err):
+			http.Error(w, utils.MsgNodeNotFound, http.StatusNotFound)
+		default:
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			http.Error(w, utils.MsgFailedUpdateNode, http.StatusInternalServerError)
+		}
+		return
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(toNode(updated, nil, false)); err != nil {
+		log.Printf(utils.LogFailedEncodeUpdatedNode, err)
+	}
+}
+
+// applyTaintChanges returns the taints after applying a request, the same way kubectl taint
+// identifies taints by key and effect
+func applyTaintChanges(current []corev1.Taint, req models.UpdateNodeTaintsRequest) ([]corev1.Taint, field.ErrorList) {
+	var errs field.ErrorList
+	taints := make([]corev1.Taint, len(current))
+	copy(taints, current)
+
+	for i, t := range req.Remove {
+		fldPath := field.NewPath("remove").Index(i)
+		errs = append(errs, validateTaintKey(t.Key, fldPath.Child("key"))...)
+		if t.Effect != "" {
+			errs = append(errs, validateTaintEffect(t.Effect, fldPath.Child("effect"))...)
+		}
+
+		kept := taints[:0]
+		for _, existing := range taints {
+			if existing.Key == t.Key && (t.Effect == "" || string(existing.Effect) == t.Effect) {
+				continue
+			}
+			kept = append(kept, existing)
+		}
+		if len(kept) == len(taints) {
+			errs = append(errs, field.NotFound(fldPath, t.Key))
+		}
+		taints = kept
+	}
+
+	seen := make(map[string]bool, len(req.Set))
+	for i, t := range req.Set {
+		fldPath := field.NewPath("set").Index(i)
+		errs = append(errs, validateTaintKey(t.Key, fldPath.Child("key"))...)
+		errs = append(errs, validateTaintEffect(t.Effect, fldPath.Child("effect"))...)
+		if t.Value != "" {
+			for _, msg := range validation.IsValidLabelValue(t.Value) {
+				errs = append(errs, field.Invalid(fldPath.Child("value"), t.Value, msg))
+			}
+		}
+		if seen[t.Key+":"+t.Effect] {
+			errs = append(errs, field.Duplicate(fldPath, t.Key+":"+t.Effect))
+		}
+		seen[t.Key+":"+t.Effect] = true
+
+		taint := corev1.Taint{Key: t.Key, Value: t.Value, Effect: corev1.TaintEffect(t.Effect)}
+		if taint.Effect == corev1.TaintEffectNoExecute {
+			// Tolerations with tolerationSeconds count from the time the taint was added
+			now := metav1.Now()
+			taint.TimeAdded = &now
+		}
+
+		replaced := false
+		for j := range taints {
+			if taints[j].Key == taint.Key && taints[j].Effect == taint.Effect {
+				if taints[j].Value == taint.Value {
+					taint.TimeAdded = taints[j].TimeAdded
+				}
+				taints[j] = taint
+				replaced = true
+				break
+			}
+		}
+		if !replaced {
+			taints = append(taints, taint)
+		}
+	}
+
+	return taints, errs
+}
+
+// validateTaintKey checks a taint key has label key syntax
+func validateTaintKey(key string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, msg := range validation.IsQualifiedName(key) {
+		errs = append(errs, field.Invalid(fldPath, key, msg))
+	}
+	return errs
+}
+
+// validateTaintEffect checks a taint effect is one the scheduler understands
+func validateTaintEffect(effect string, fldPath *field.Path) field.ErrorList {
+	switch corev1.TaintEffect(effect) {
+	case corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+		return nil
+	case "":
+		return field.ErrorList{field.Required(fldPath, "effect is required")}
+	}
+	return field.ErrorList{field.NotSupported(fldPath, effect, []string{"NoSchedule", "PreferNoSchedule", "NoExecute"})}
+}
+
+// scheduledPodsByNode lists the pods that still hold resources, grouped by node. An empty nodeName lists all nodes.
+func scheduledPodsByNode(ctx context.Context, clientset *kubernetes.Clientset, nodeName string) (map[string][]corev1.Pod, error) {
+	selectors := []fields.Selector{
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodSucceeded)),
+		fields.OneTermNotEqualSelector("status.phase", string(corev1.PodFailed)),
+	}
+	if nodeName != "" {
+		selectors = append(selectors, fields.OneTermEqualSelector("spec.nodeName", nodeName))
+	}
+
+	pods, err := clientset.CoreV1().Pods("").List(ctx, metav1.ListOptions{FieldSelector: fields.AndSelectors(selectors...).String()})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make(map[string][]corev1.Pod)
+	for _, pod := range pods.Items {
+		if pod.Spec.NodeName != "" {
+			result[pod.Spec.NodeName] = append(result[pod.Spec.NodeName], pod)
+		}
+	}
+	return result, nil
+}
+
+// podRequestsAndLimits returns the effective requests and limits of a pod the way the scheduler
+// counts them: the sum of its containers, at least the largest init container, plus overhead
+func podRequestsAndLimits(pod *corev1.Pod) (corev1.ResourceList, corev1.ResourceList) {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+
+	for _, c := range pod.Spec.Containers {
+		addResourceList(requests, c.Resources.Requests)
+		addResourceList(limits, c.Resources.Limits)
+	}
+	for _, c := range pod.Spec.InitContainers {
+		maxResourceList(requests, c.Resources.Requests)
+		maxResourceList(limits, c.Resources.Limits)
+	}
+	if pod.Spec.Overhead != nil {
+		addResourceList(requests, pod.Spec.Overhead)
+		addResourceList(limits, pod.Spec.Overhead)
+	}
+	return requests, limits
+}
+
+// addResourceList adds every quantity of add to list
+func addResourceList(list, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if current, ok := list[name]; ok {
+			current.Add(quantity)
+			list[name] = current
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// maxResourceList raises every quantity of list to at least the one in other
+func maxResourceList(list, other corev1.ResourceList) {
+	for name, quantity := range other {
+		if current, ok := list[name]; !ok || quantity.Cmp(current) > 0 {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+// nodeAllocatedResources sums the requests and limits of the pods on a node, like kubectl describe node
+func nodeAllocatedResources(node *corev1.Node, pods []corev1.Pod) *models.NodeAllocatedResources {
+	requests := corev1.ResourceList{}
+	limits := corev1.ResourceList{}
+	for i := range pods {
+		podRequests, podLimits := podRequestsAndLimits(&pods[i])
+		addResourceList(requests, podRequests)
+		addResourceList(limits, podLimits)
+	}
+
+	podCapacity := node.Status.Allocatable[corev1.ResourcePods]
+	return &models.NodeAllocatedResources{
+		CPU:         allocatedResource(requests[corev1.ResourceCPU], limits[corev1.ResourceCPU], node.Status.Allocatable[corev1.ResourceCPU], true),
+		Memory:      allocatedResource(requests[corev1.ResourceMemory], limits[corev1.ResourceMemory], node.Status.Allocatable[corev1.ResourceMemory], false),
+		Pods:        len(pods),
+		PodCapacity: podCapacity.Value(),
+	}
+}
+
+// allocatedResource compares requests and limits with allocatable; CPU is compared in millicores
+func allocatedResource(requests, limits, allocatable resource.Quantity, milli bool) models.AllocatedResource {
+	return models.AllocatedResource{
+		Requests:           requests.String(),
+		Limits:             limits.String(),
+		Allocatable:        allocatable.String(),
+		RequestsPercentage: quantityPercentage(requests, allocatable, milli),
+		LimitsPercentage:   quantityPercentage(limits, allocatable, milli),
+	}
+}
+
+// quantityPercentage returns part as a percentage of total, or 0 when total is zero
+func quantityPercentage(part, total resource.Quantity, milli bool) float64 {
+	if milli {
+		if total.MilliValue() == 0 {
+			return 0
+		}
+		return float64(part.MilliValue()) / float64(total.MilliValue()) * 100
+	}
+	if total.Value() == 0 {
+		return 0
+	}
+	return float64(part.Value()) / float64(total.Value()) * 100
+}
+
+// nodeRoles derives roles from node-role.kubernetes.io/<role> and kubernetes.io/role labels
+func nodeRoles(nodeLabels map[string]string) []string {
+	seen := make(map[string]bool)
+	roles := []string{}
+	for k, v := range nodeLabels {
+		role := ""
+		switch {
+		case strings.HasPrefix(k, nodeRoleLabelPrefix):
+			role = strings.TrimPrefix(k, nodeRoleLabelPrefix)
+		case k == nodeRoleLabel:
+			role = v
+		}
+		if role != "" && !seen[role] {
+			seen[role] = true
+			roles = append(roles, role)
+		}
+	}
+	sort.Strings(roles)
+	return roles
+}
+
+// nodeStatus maps the Ready condition to Ready, NotReady or Unknown
+func nodeStatus(node *corev1.Node) string {
+	for _, c := range node.Status.Conditions {
+		if c.Type != corev1.NodeReady {
+			continue
+		}
+		switch c.Status {
+		case corev1.ConditionTrue:
+			return "Ready"
+		case corev1.ConditionFalse:
+			return "NotReady"
+		}
+	}
+	return "Unknown"
+}
+
+// toNode converts a node into the simplified view; allocation is only reported when withPods is set
+func toNode(n *corev1.Node, pods []corev1.Pod, withPods bool) models.Node {
+	capacity := make(map[string]string)
+	allocatable := make(map[string]string)
+
+	for resourceName, quantity := range n.Status.Capacity {
+		capacity[string(resourceName)] = quantity.String()
+	}
+	for resourceName, quantity := range n.Status.Allocatable {
+		allocatable[string(resourceName)] = quantity.String()
+	}
+
+	taints := make([]models.NodeTaint, 0, len(n.Spec.Taints))
+	for _, t := range n.Spec.Taints {
+		taint := models.NodeTaint{Key: t.Key, Value: t.Value, Effect: string(t.Effect)}
+		if t.TimeAdded != nil {
+			taint.TimeAdded = t.TimeAdded.Time.Format(time.RFC3339)
+		}
+		taints = append(taints, taint)
+	}
+
+	conditions := make([]models.NodeCondition, 0, len(n.Status.Conditions))
+	var pressures []string
+	for _, c := range n.Status.Conditions {
+		conditions = append(conditions, models.NodeCondition{
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastTransitionTime: c.LastTransitionTime.Time.Format(time.RFC3339),
+		})
+		if c.Type != corev1.NodeReady && c.Status == corev1.ConditionTrue {
+			pressures = append(pressures, string(c.Type))
+		}
+	}
+
+	addresses := make([]models.NodeAddress, 0, len(n.Status.Addresses))
+	internalIP, externalIP := "", ""
+	for _, a := range n.Status.Addresses {
+		addresses = append(addresses, models.NodeAddress{Type: string(a.Type), Address: a.Address})
+		if a.Type == corev1.NodeInternalIP && internalIP == "" {
+			internalIP = a.Address
+		}
+		if a.Type == corev1.NodeExternalIP && externalIP == "" {
+			externalIP = a.Address
+		}
+	}
+
+	node := models.Node{
+		Name:                    n.Name,
+		Status:                  nodeStatus(n),
+		Conditions:              conditions,
+		Pressures:               pressures,
+		Roles:                   nodeRoles(n.Labels),
+		Addresses:               addresses,
+		InternalIP:              internalIP,
+		ExternalIP:              externalIP,
+		Version:                 n.Status.NodeInfo.KubeletVersion,
+		OSImage:                 n.Status.NodeInfo.OSImage,
+		KernelVersion:           n.Status.NodeInfo.KernelVersion,
+		ContainerRuntimeVersion: n.Status.NodeInfo.ContainerRuntimeVersion,
+		Architecture:            n.Status.NodeInfo.Architecture,
+		Capacity:                capacity,
+		Allocatable:             allocatable,
+		CreatedAt:               n.CreationTimestamp.Time.Format(time.RFC3339),
+		Labels:                  n.Labels,
+		Unschedulable:           n.Spec.Unschedulable,
+		Taints:                  taints,
+		ResourceVersion:         n.ResourceVersion,
+	}
+	if withPods {
+		node.Allocated = nodeAllocatedResources(n, pods)
+	}
+	return node
+}
@@ -2,16 +2,21 @@ package api
 
 import (
 	"encoding/json"
+	"errors"
 	"fmt"
+	"io"
 	"k8_gui/internal/models"
 	"k8_gui/internal/utils"
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	corev1 "k8s.io/api/core/v1"
+	policyv1 "k8s.io/api/policy/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/kubernetes"
 )
@@ -28,27 +33,7 @@ func ListPods(clientset *kubernetes.Clientset) http.HandlerFunc {
 
 		response := models.PodListResponse{Items: make([]models.Pod, 0, len(pods.Items))}
 		for _, p := range pods.Items {
-			restartCount := int32(0)
-			for _, cs := range p.Status.ContainerStatuses {
-				restartCount += cs.RestartCount
-			}
-
-			containers := make([]string, len(p.Spec.Containers))
-			for i, c := range p.Spec.Containers {
-				containers[i] = c.Name
-			}
-
-			response.Items = append(response.Items, models.Pod{
-				Name:         p.Name,
-				Namespace:    p.Namespace,
-				Status:       string(p.Status.Phase),
-				RestartCount: restartCount,
-				CreatedAt:    p.CreationTimestamp.Time.Format(time.RFC3339),
-				NodeName:     p.Spec.NodeName,
-				PodIP:        p.Status.PodIP,
-				Containers:   containers,
-				Labels:       p.Labels,
-			})
+			response.Items = append(response.Items, toPod(&p))
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -72,26 +57,40 @@ func GetPod(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
-		restartCount := int32(0)
-		for _, cs := range pod.Status.ContainerStatuses {
-			restartCount += cs.RestartCount
+		response := models.PodDetail{
+			Pod:                   toPod(pod),
+			HostIP:                pod.Status.HostIP,
+			QOSClass:              string(pod.Status.QOSClass),
+			ServiceAccount:        pod.Spec.ServiceAccountName,
+			Annotations:           pod.Annotations,
+			InitContainerStatuses: containerDetails(pod.Spec.InitContainers, pod.Status.InitContainerStatuses),
+			ContainerStatuses:     containerDetails(pod.Spec.Containers, pod.Status.ContainerStatuses),
+			Conditions:            make([]models.PodCondition, 0, len(pod.Status.Conditions)),
+			Volumes:               make([]models.PodVolume, 0, len(pod.Spec.Volumes)),
+			OwnerReferences:       ownerReferences(pod.OwnerReferences),
+			Tolerations:           make([]models.Toleration, 0, len(pod.Spec.Tolerations)),
 		}
 
-		containers := make([]string, len(pod.Spec.Containers))
-		for i, c := range pod.Spec.Containers {
-			containers[i] = c.Name
+		for _, c := range pod.Status.Conditions {
+			response.Conditions = append(response.Conditions, models.PodCondition{
+				Type:               string(c.Type),
+				Status:             string(c.Status),
+				Reason:             c.Reason,
+				Message:            c.Message,
+				LastTransitionTime: formatTime(c.LastTransitionTime),
+			})
 		}
-
-		response := models.Pod{
-			Name:         pod.Name,
-			Namespace:    pod.Namespace,
-			Status:       string(pod.Status.Phase),
-			RestartCount: restartCount,
-			CreatedAt:    pod.CreationTimestamp.Time.Format(time.RFC3339),
-			NodeName:     pod.Spec.NodeName,
-			PodIP:        pod.Status.PodIP,
-			Containers:   containers,
-			Labels:       pod.Labels,
+		for _, v := range pod.Spec.Volumes {
+			response.Volumes = append(response.Volumes, podVolume(v))
+		}
+		for _, t := range pod.Spec.Tolerations {
+			response.Tolerations = append(response.Tolerations, models.Toleration{
+				Key:               t.Key,
+				Operator:          string(t.Operator),
+				Value:             t.Value,
+				Effect:            string(t.Effect),
+				TolerationSeconds: t.TolerationSeconds,
+			})
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -101,16 +100,26 @@ func GetPod(clientset *kubernetes.Clientset) http.HandlerFunc {
 	}
 }
 
-// DeletePod deletes a pod
+// DeletePod deletes a pod, honoring optional gracePeriodSeconds, force and propagationPolicy query parameters
 func DeletePod(clientset *kubernetes.Clientset) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		vars := mux.Vars(r)
 		namespace := vars["namespace"]
 		name := vars["name"]
 
-		err := clientset.CoreV1().Pods(namespace).Delete(r.Context(), name, metav1.DeleteOptions{})
+		opts, err := parseDeleteOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = clientset.CoreV1().Pods(namespace).Delete(r.Context(), name, opts)
 		if err != nil {
 			log.Printf(utils.LogFailedDeletePod, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgPodNotFound, http.StatusNotFound)
+				return
+			}
 			http.Error(w, utils.MsgFailedDeletePod, http.StatusInternalServerError)
 			return
 		}
@@ -119,6 +128,61 @@ func DeletePod(clientset *kubernetes.Clientset) http.HandlerFunc {
 	}
 }
 
+// EvictPod evicts a pod through the policy/v1 Eviction API so PodDisruptionBudgets are respected
+func EvictPod(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		var req models.EvictPodRequest
+		if err := decodeOptionalBody(r, &req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+		if req.GracePeriodSeconds != nil && *req.GracePeriodSeconds < 0 {
+			http.Error(w, utils.MsgInvalidGracePeriod, http.StatusBadRequest)
+			return
+		}
+
+		eviction := &policyv1.Eviction{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      name,
+				Namespace: namespace,
+			},
+		}
+		if req.GracePeriodSeconds != nil {
+			eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: req.GracePeriodSeconds}
+		}
+
+		err := clientset.PolicyV1().Evictions(namespace).Evict(r.Context(), eviction)
+		if err != nil {
+			log.Printf(utils.LogFailedEvictPod, name, err)
+			switch {
+			case apierrors.IsNotFound(err):
+				http.Error(w, utils.MsgPodNotFound, http.StatusNotFound)
+			case apierrors.IsTooManyRequests(err):
+				// The API server answers 429 when a PodDisruptionBudget does not allow the disruption
+				http.Error(w, fmt.Sprintf("%s: %s", utils.MsgEvictionBlockedByPDB, apierrors.ReasonForError(err)), http.StatusTooManyRequests)
+			default:
+				http.Error(w, utils.MsgFailedEvictPod, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.WriteHeader(http.StatusAccepted)
+	}
+}
+
+// decodeOptionalBody decodes a JSON request body that may be omitted entirely. An empty body,
+// including an empty chunked one, leaves v untouched.
+func decodeOptionalBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
+
 // GetPodLogs returns pod logs
 func GetPodLogs(clientset *kubernetes.Clientset) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -153,6 +217,44 @@ func GetPodLogs(clientset *kubernetes.Clientset) http.HandlerFunc {
 	}
 }
 
+// parseDeleteOptions builds delete options from the gracePeriodSeconds, force and propagationPolicy query parameters
+func parseDeleteOptions(r *http.Request) (metav1.DeleteOptions, error) {
+	opts := metav1.DeleteOptions{}
+	query := r.URL.Query()
+
+	if grace := query.Get("gracePeriodSeconds"); grace != "" {
+		seconds, err := strconv.ParseInt(grace, 10, 64)
+		if err != nil || seconds < 0 {
+			return opts, errors.New(utils.MsgInvalidGracePeriod)
+		}
+		opts.GracePeriodSeconds = &seconds
+	}
+
+	if force := query.Get("force"); force != "" {
+		forced, err := strconv.ParseBool(force)
+		if err != nil {
+			return opts, errors.New(utils.MsgInvalidForceParameter)
+		}
+		// Same as kubectl delete --force: skip graceful termination entirely
+		if forced {
+			zero := int64(0)
+			opts.GracePeriodSeconds = &zero
+		}
+	}
+
+	if policy := query.Get("propagationPolicy"); policy != "" {
+		p := metav1.DeletionPropagation(policy)
+		switch p {
+		case metav1.DeletePropagationOrphan, metav1.DeletePropagationBackground, metav1.DeletePropagationForeground:
+			opts.PropagationPolicy = &p
+		default:
+			return opts, errors.New(utils.MsgInvalidPropagationPolicy)
+		}
+	}
+
+	return opts, nil
+}
+
 // Helper function to parse tail lines parameter
 func parseTailLines(tail string) (int64, error) {
 	if tail == "" {
@@ -164,3 +266,312 @@ func parseTailLines(tail string) (int64, error) {
 	}
 	return result, nil
 }
+
+// toPod converts a pod into the simplified list view
+func toPod(pod *corev1.Pod) models.Pod {
+	restartCount := int32(0)
+	for _, cs := range pod.Status.ContainerStatuses {
+		restartCount += cs.RestartCount
+	}
+
+	containers := make([]string, len(pod.Spec.Containers))
+	for i, c := range pod.Spec.Containers {
+		containers[i] = c.Name
+	}
+
+	status, ready := podDisplayStatus(pod)
+
+	return models.Pod{
+		Name:         pod.Name,
+		Namespace:    pod.Namespace,
+		Status:       status,
+		Phase:        string(pod.Status.Phase),
+		Ready:        ready,
+		RestartCount: restartCount,
+		CreatedAt:    pod.CreationTimestamp.Time.Format(time.RFC3339),
+		NodeName:     pod.Spec.NodeName,
+		PodIP:        pod.Status.PodIP,
+		Containers:   containers,
+		Labels:       pod.Labels,
+	}
+}
+
+// podDisplayStatus computes the STATUS and READY columns the way kubectl get pods does
+func podDisplayStatus(pod *corev1.Pod) (string, string) {
+	totalContainers := len(pod.Spec.Containers)
+	readyContainers := 0
+
+	reason := string(pod.Status.Phase)
+	if pod.Status.Reason != "" {
+		reason = pod.Status.Reason
+	}
+
+	// Sidecars (restartable init containers) count towards READY once started
+	restartable := make(map[string]bool)
+	for _, c := range pod.Spec.InitContainers {
+		if c.RestartPolicy != nil && *c.RestartPolicy == corev1.ContainerRestartPolicyAlways {
+			restartable[c.Name] = true
+			totalContainers++
+		}
+	}
+
+	initializing := false
+	for i, container := range pod.Status.InitContainerStatuses {
+		if restartable[container.Name] && container.Started != nil && *container.Started && container.Ready {
+			readyContainers++
+		}
+
+		switch {
+		case container.State.Terminated != nil && container.State.Terminated.ExitCode == 0:
+			continue
+		case restartable[container.Name] && container.Started != nil && *container.Started:
+			continue
+		case container.State.Terminated != nil:
+			if container.State.Terminated.Reason == "" {
+				if container.State.Terminated.Signal != 0 {
+					reason = fmt.Sprintf("Init:Signal:%d", container.State.Terminated.Signal)
+				} else {
+					reason = fmt.Sprintf("Init:ExitCode:%d", container.State.Terminated.ExitCode)
+				}
+			} else {
+				reason = "Init:" + container.State.Terminated.Reason
+			}
+			initializing = true
+		case container.State.Waiting != nil && container.State.Waiting.Reason != "" && container.State.Waiting.Reason != "PodInitializing":
+			reason = "Init:" + container.State.Waiting.Reason
+			initializing = true
+		default:
+			reason = fmt.Sprintf("Init:%d/%d", i, len(pod.Spec.InitContainers))
+			initializing = true
+		}
+		break
+	}
+
+	if !initializing || podConditionTrue(pod, corev1.PodInitialized) {
+		hasRunning := false
+		for i := len(pod.Status.ContainerStatuses) - 1; i >= 0; i-- {
+			container := pod.Status.ContainerStatuses[i]
+			switch {
+			case container.State.Waiting != nil && container.State.Waiting.Reason != "":
+				reason = container.State.Waiting.Reason
+			case container.State.Terminated != nil && container.State.Terminated.Reason != "":
+				reason = container.State.Terminated.Reason
+			case container.State.Terminated != nil:
+				if container.State.Terminated.Signal != 0 {
+					reason = fmt.Sprintf("Signal:%d", container.State.Terminated.Signal)
+				} else {
+					reason = fmt.Sprintf("ExitCode:%d", container.State.Terminated.ExitCode)
+				}
+			case container.Ready && container.State.Running != nil:
+				hasRunning = true
+				readyContainers++
+			}
+		}
+
+		// A completed container next to a still-running one leaves the pod running
+		if reason == "Completed" && hasRunning {
+			if podConditionTrue(pod, corev1.PodReady) {
+				reason = "Running"
+			} else {
+				reason = "NotReady"
+			}
+		}
+	}
+
+	if pod.DeletionTimestamp != nil && pod.Status.Reason == "NodeLost" {
+		reason = "Unknown"
+	} else if pod.DeletionTimestamp != nil {
+		reason = "Terminating"
+	}
+
+	return reason, fmt.Sprintf("%d/%d", readyContainers, totalContainers)
+}
+
+// podConditionTrue reports whether the given pod condition is True
+func podConditionTrue(pod *corev1.Pod, conditionType corev1.PodConditionType) bool {
+	for _, c := range pod.Status.Conditions {
+		if c.Type == conditionType {
+			return c.Status == corev1.ConditionTrue
+		}
+	}
+	return false
+}
+
+// containerDetails merges container specs with their runtime statuses
+func containerDetails(specs []corev1.Container, statuses []corev1.ContainerStatus) []models.ContainerDetail {
+	byName := make(map[string]corev1.ContainerStatus, len(statuses))
+	for _, cs := range statuses {
+		byName[cs.Name] = cs
+	}
+
+	details := make([]models.ContainerDetail, 0, len(specs))
+	for _, c := range specs {
+		detail := models.ContainerDetail{
+			Name:           c.Name,
+			Image:          c.Image,
+			Requests:       resourceListToMap(c.Resources.Requests),
+			Limits:         resourceListToMap(c.Resources.Limits),
+			LivenessProbe:  probeDetail(c.LivenessProbe),
+			ReadinessProbe: probeDetail(c.ReadinessProbe),
+			StartupProbe:   probeDetail(c.StartupProbe),
+			State:          models.ContainerState{State: "waiting"},
+		}
+
+		for _, p := range c.Ports {
+			detail.Ports = append(detail.Ports, models.ContainerPort{
+				Name:          p.Name,
+				ContainerPort: p.ContainerPort,
+				Protocol:      string(p.Protocol),
+			})
+		}
+		for _, m := range c.VolumeMounts {
+			detail.VolumeMounts = append(detail.VolumeMounts, models.VolumeMount{
+				Name:      m.Name,
+				MountPath: m.MountPath,
+				SubPath:   m.SubPath,
+				ReadOnly:  m.ReadOnly,
+			})
+		}
+
+		if cs, ok := byName[c.Name]; ok {
+			detail.ImageID = cs.ImageID
+			detail.Ready = cs.Ready
+			detail.Started = cs.Started != nil && *cs.Started
+			detail.RestartCount = cs.RestartCount
+			detail.State = containerState(cs.State)
+			if last := containerState(cs.LastTerminationState); last.State != "" {
+				detail.LastState = &last
+			}
+		}
+
+		details = append(details, detail)
+	}
+	return details
+}
+
+// containerState flattens a container state into whichever variant is set
+func containerState(s corev1.ContainerState) models.ContainerState {
+	switch {
+	case s.Running != nil:
+		return models.ContainerState{
+			State:     "running",
+			StartedAt: formatTime(s.Running.StartedAt),
+		}
+	case s.Terminated != nil:
+		exitCode := s.Terminated.ExitCode
+		return models.ContainerState{
+			State:      "terminated",
+			Reason:     s.Terminated.Reason,
+			Message:    s.Terminated.Message,
+			ExitCode:   &exitCode,
+			Signal:     s.Terminated.Signal,
+			StartedAt:  formatTime(s.Terminated.StartedAt),
+			FinishedAt: formatTime(s.Terminated.FinishedAt),
+		}
+	case s.Waiting != nil:
+		return models.ContainerState{
+			State:   "waiting",
+			Reason:  s.Waiting.Reason,
+			Message: s.Waiting.Message,
+		}
+	}
+	return models.ContainerState{}
+}
+
+// probeDetail describes a probe and its handler in kubectl describe style
+func probeDetail(p *corev1.Probe) *models.Probe {
+	if p == nil {
+		return nil
+	}
+
+	probe := &models.Probe{
+		InitialDelaySeconds: p.InitialDelaySeconds,
+		PeriodSeconds:       p.PeriodSeconds,
+		TimeoutSeconds:      p.TimeoutSeconds,
+		SuccessThreshold:    p.SuccessThreshold,
+		FailureThreshold:    p.FailureThreshold,
+	}
+
+	switch {
+	case p.HTTPGet != nil:
+		scheme := strings.ToLower(string(p.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = "http"
+		}
+		probe.Type = "httpGet"
+		probe.Handler = fmt.Sprintf("%s://%s:%s%s", scheme, p.HTTPGet.Host, p.HTTPGet.Port.String(), p.HTTPGet.Path)
+	case p.TCPSocket != nil:
+		probe.Type = "tcpSocket"
+		probe.Handler = fmt.Sprintf("tcp %s:%s", p.TCPSocket.Host, p.TCPSocket.Port.String())
+	case p.Exec != nil:
+		probe.Type = "exec"
+		probe.Handler = strings.Join(p.Exec.Command, " ")
+	case p.GRPC != nil:
+		probe.Type = "grpc"
+		probe.Handler = fmt.Sprintf("grpc :%d", p.GRPC.Port)
+	}
+	return probe
+}
+
+// podVolume reports the source type of a volume and what it points at
+func podVolume(v corev1.Volume) models.PodVolume {
+	vol := models.PodVolume{Name: v.Name}
+	switch {
+	case v.ConfigMap != nil:
+		vol.Type, vol.Source = "ConfigMap", v.ConfigMap.Name
+	case v.Secret != nil:
+		vol.Type, vol.Source = "Secret", v.Secret.SecretName
+	case v.PersistentVolumeClaim != nil:
+		vol.Type, vol.Source = "PersistentVolumeClaim", v.PersistentVolumeClaim.ClaimName
+	case v.EmptyDir != nil:
+		vol.Type = "EmptyDir"
+	case v.HostPath != nil:
+		vol.Type, vol.Source = "HostPath", v.HostPath.Path
+	case v.Projected != nil:
+		vol.Type = "Projected"
+	case v.DownwardAPI != nil:
+		vol.Type = "DownwardAPI"
+	case v.NFS != nil:
+		vol.Type, vol.Source = "NFS", v.NFS.Server+":"+v.NFS.Path
+	case v.CSI != nil:
+		vol.Type, vol.Source = "CSI", v.CSI.Driver
+	case v.Ephemeral != nil:
+		vol.Type = "Ephemeral"
+	default:
+		vol.Type = "Other"
+	}
+	return vol
+}
+
+// ownerReferences converts object owner references
+func ownerReferences(refs []metav1.OwnerReference) []models.OwnerReference {
+	owners := make([]models.OwnerReference, 0, len(refs))
+	for _, ref := range refs {
+		owners = append(owners, models.OwnerReference{
+			Kind:       ref.Kind,
+			Name:       ref.Name,
+			Controller: ref.Controller != nil && *ref.Controller,
+		})
+	}
+	return owners
+}
+
+// resourceListToMap converts a resource list into a name -> quantity string map
+func resourceListToMap(list corev1.ResourceList) map[string]string {
+	if len(list) == 0 {
+		return nil
+	}
+	result := make(map[string]string, len(list))
+	for resourceName, quantity := range list {
+		result[string(resourceName)] = quantity.String()
+	}
+	return result
+}
+
+// formatTime formats a timestamp as RFC3339, returning an empty string when unset
+func formatTime(t metav1.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.Time.Format(time.RFC3339)
+}
@@ -0,0 +1,439 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apiequality "k8s.io/apimachinery/pkg/api/equality"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	revisionAnnotation    = "deployment.kubernetes.io/revision"
+	changeCauseAnnotation = "kubernetes.io/change-cause"
+
+	defaultRolloutWatchTimeout = 10 * time.Minute
+	maxRolloutWatchTimeout     = 30 * time.Minute
+)
+
+// GetDeploymentHistory lists the ReplicaSet revisions of a deployment, newest first
+func GetDeploymentHistory(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetDeployment, err)
+			http.Error(w, utils.MsgDeploymentNotFound, http.StatusNotFound)
+			return
+		}
+
+		replicaSets, err := deploymentReplicaSets(r.Context(), clientset, deployment)
+		if err != nil {
+			log.Printf(utils.LogFailedListReplicaSets, name, err)
+			http.Error(w, utils.MsgFailedGetDeploymentHistory, http.StatusInternalServerError)
+			return
+		}
+
+		currentRevision := deployment.Annotations[revisionAnnotation]
+		response := models.DeploymentHistoryResponse{
+			Name:            deployment.Name,
+			Namespace:       deployment.Namespace,
+			CurrentRevision: parseRevision(currentRevision),
+			Items:           make([]models.DeploymentRevision, 0, len(replicaSets)),
+		}
+
+		for _, rs := range replicaSets {
+			images := make([]string, 0, len(rs.Spec.Template.Spec.Containers))
+			for _, c := range rs.Spec.Template.Spec.Containers {
+				images = append(images, c.Image)
+			}
+
+			diff, err := templateDiff(deployment.Spec.Template, rs.Spec.Template)
+			if err != nil {
+				log.Printf(utils.LogFailedDiffTemplate, rs.Name, err)
+			}
+
+			replicas := int32(0)
+			if rs.Spec.Replicas != nil {
+				replicas = *rs.Spec.Replicas
+			}
+
+			response.Items = append(response.Items, models.DeploymentRevision{
+				Revision:    parseRevision(rs.Annotations[revisionAnnotation]),
+				ReplicaSet:  rs.Name,
+				ChangeCause: rs.Annotations[changeCauseAnnotation],
+				Images:      images,
+				Replicas:    replicas,
+				CreatedAt:   rs.CreationTimestamp.Time.Format(time.RFC3339),
+				Current:     rs.Annotations[revisionAnnotation] == currentRevision,
+				Diff:        diff,
+			})
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeDeploymentHistory, err)
+		}
+	}
+}
+
+// RollbackDeployment restores the pod template of a previous revision, like kubectl rollout undo
+func RollbackDeployment(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		var req models.RollbackDeploymentRequest
+		if err := decodeOptionalBody(r, &req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+		if req.Revision < 0 {
+			http.Error(w, utils.MsgInvalidRevision, http.StatusBadRequest)
+			return
+		}
+
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetDeployment, err)
+			http.Error(w, utils.MsgDeploymentNotFound, http.StatusNotFound)
+			return
+		}
+		if deployment.Spec.Paused {
+			http.Error(w, utils.MsgDeploymentPausedRollback, http.StatusConflict)
+			return
+		}
+
+		replicaSets, err := deploymentReplicaSets(r.Context(), clientset, deployment)
+		if err != nil {
+			log.Printf(utils.LogFailedListReplicaSets, name, err)
+			http.Error(w, utils.MsgFailedRollbackDeployment, http.StatusInternalServerError)
+			return
+		}
+
+		target := findRevision(replicaSets, parseRevision(deployment.Annotations[revisionAnnotation]), req.Revision)
+		if target == nil {
+			http.Error(w, utils.MsgRevisionNotFound, http.StatusNotFound)
+			return
+		}
+
+		template := *target.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+		if apiequality.Semantic.DeepEqual(template, deployment.Spec.Template) {
+			w.Header().Set("Content-Type", "application/json")
+			if err := json.NewEncoder(w).Encode(toDeployment(deployment)); err != nil {
+				log.Printf(utils.LogFailedEncodeUpdatedDeployment, err)
+			}
+			return
+		}
+
+		// Carrying the observed resourceVersion makes the API server reject the patch
+		// with a conflict if the deployment changed since it was read
+		patch := []map[string]interface{}{
+			{"op": "replace", "path": "/metadata/resourceVersion", "value": deployment.ResourceVersion},
+			{"op": "replace", "path": "/spec/template", "value": template},
+		}
+		if cause, ok := target.Annotations[changeCauseAnnotation]; ok {
+			annotations := make(map[string]string, len(deployment.Annotations)+1)
+			for k, v := range deployment.Annotations {
+				annotations[k] = v
+			}
+			annotations[changeCauseAnnotation] = cause
+			patch = append(patch, map[string]interface{}{"op": "replace", "path": "/metadata/annotations", "value": annotations})
+		}
+
+		data, err := json.Marshal(patch)
+		if err != nil {
+			log.Printf(utils.LogFailedRollbackDeployment, name, err)
+			http.Error(w, utils.MsgFailedRollbackDeployment, http.StatusInternalServerError)
+			return
+		}
+
+		patched, err := clientset.AppsV1().Deployments(namespace).Patch(r.Context(), name, types.JSONPatchType, data, metav1.PatchOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedRollbackDeployment, name, err)
+			if apierrors.IsConflict(err) {
+				http.Error(w, utils.MsgDeploymentModified, http.StatusConflict)
+				return
+			}
+			http.Error(w, utils.MsgFailedRollbackDeployment, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toDeployment(patched)); err != nil {
+			log.Printf(utils.LogFailedEncodeUpdatedDeployment, err)
+		}
+	}
+}
+
+// GetDeploymentRolloutStatus reports rollout progress with kubectl rollout status semantics
+func GetDeploymentRolloutStatus(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetDeployment, err)
+			http.Error(w, utils.MsgDeploymentNotFound, http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(rolloutStatus(deployment)); err != nil {
+			log.Printf(utils.LogFailedEncodeRolloutStatus, err)
+		}
+	}
+}
+
+// StreamDeploymentRolloutStatus streams rollout status as server-sent events until the
+// rollout completes, fails, the timeout elapses or the client goes away
+func StreamDeploymentRolloutStatus(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		timeout := defaultRolloutWatchTimeout
+		if t := r.URL.Query().Get("timeout"); t != "" {
+			parsed, err := time.ParseDuration(t)
+			if err != nil || parsed <= 0 || parsed > maxRolloutWatchTimeout {
+				http.Error(w, utils.MsgInvalidTimeoutParameter, http.StatusBadRequest)
+				return
+			}
+			timeout = parsed
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			http.Error(w, utils.MsgStreamingUnsupported, http.StatusInternalServerError)
+			return
+		}
+
+		deployment, err := clientset.AppsV1().Deployments(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetDeployment, err)
+			http.Error(w, utils.MsgDeploymentNotFound, http.StatusNotFound)
+			return
+		}
+
+		ctx, cancel := context.WithTimeout(r.Context(), timeout)
+		defer cancel()
+
+		watcher, err := clientset.AppsV1().Deployments(namespace).Watch(ctx, metav1.ListOptions{
+			FieldSelector:   fields.OneTermEqualSelector("metadata.name", name).String(),
+			ResourceVersion: deployment.ResourceVersion,
+		})
+		if err != nil {
+			log.Printf(utils.LogFailedWatchDeployment, name, err)
+			http.Error(w, utils.MsgFailedWatchDeployment, http.StatusInternalServerError)
+			return
+		}
+		defer watcher.Stop()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.Header().Set("Connection", "keep-alive")
+
+		status := rolloutStatus(deployment)
+		if err := writeSSE(w, flusher, "status", status); err != nil || status.Done || status.Failed {
+			return
+		}
+
+		for {
+			select {
+			case <-ctx.Done():
+				if errors.Is(ctx.Err(), context.DeadlineExceeded) {
+					writeSSE(w, flusher, "timeout", status)
+				}
+				return
+			case event, ok := <-watcher.ResultChan():
+				if !ok {
+					return
+				}
+				switch event.Type {
+				case watch.Deleted:
+					writeSSE(w, flusher, "deleted", status)
+					return
+				case watch.Added, watch.Modified:
+					d, ok := event.Object.(*appsv1.Deployment)
+					if !ok {
+						continue
+					}
+					status = rolloutStatus(d)
+					if err := writeSSE(w, flusher, "status", status); err != nil || status.Done || status.Failed {
+						return
+					}
+				}
+			}
+		}
+	}
+}
+
+// rolloutStatus mirrors the checks kubectl rollout status performs for deployments
+func rolloutStatus(d *appsv1.Deployment) models.RolloutStatus {
+	status := models.RolloutStatus{
+		Name:                d.Name,
+		Namespace:           d.Namespace,
+		Generation:          d.Generation,
+		ObservedGeneration:  d.Status.ObservedGeneration,
+		Replicas:            d.Status.Replicas,
+		UpdatedReplicas:     d.Status.UpdatedReplicas,
+		ReadyReplicas:       d.Status.ReadyReplicas,
+		AvailableReplicas:   d.Status.AvailableReplicas,
+		UnavailableReplicas: d.Status.UnavailableReplicas,
+		Paused:              d.Spec.Paused,
+		Conditions:          make([]models.DeploymentCondition, 0, len(d.Status.Conditions)),
+	}
+	if d.Spec.Replicas != nil {
+		status.DesiredReplicas = *d.Spec.Replicas
+	}
+
+	var progressing *appsv1.DeploymentCondition
+	for i, c := range d.Status.Conditions {
+		if c.Type == appsv1.DeploymentProgressing {
+			progressing = &d.Status.Conditions[i]
+		}
+		status.Conditions = append(status.Conditions, models.DeploymentCondition{
+			Type:               string(c.Type),
+			Status:             string(c.Status),
+			Reason:             c.Reason,
+			Message:            c.Message,
+			LastUpdateTime:     formatTime(c.LastUpdateTime),
+			LastTransitionTime: formatTime(c.LastTransitionTime),
+		})
+	}
+
+	switch {
+	case d.Generation > d.Status.ObservedGeneration:
+		status.Message = "Waiting for deployment spec update to be observed..."
+	case progressing != nil && progressing.Reason == "ProgressDeadlineExceeded":
+		status.Failed = true
+		status.ProgressDeadlineExceeded = true
+		status.Message = fmt.Sprintf("deployment %q exceeded its progress deadline", d.Name)
+	case d.Spec.Replicas != nil && d.Status.UpdatedReplicas < *d.Spec.Replicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d out of %d new replicas have been updated...",
+			d.Name, d.Status.UpdatedReplicas, *d.Spec.Replicas)
+	case d.Status.Replicas > d.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d old replicas are pending termination...",
+			d.Name, d.Status.Replicas-d.Status.UpdatedReplicas)
+	case d.Status.AvailableReplicas < d.Status.UpdatedReplicas:
+		status.Message = fmt.Sprintf("Waiting for deployment %q rollout to finish: %d of %d updated replicas are available...",
+			d.Name, d.Status.AvailableReplicas, d.Status.UpdatedReplicas)
+	default:
+		status.Done = true
+		status.Message = fmt.Sprintf("deployment %q successfully rolled out", d.Name)
+	}
+	return status
+}
+
+// writeSSE writes a single server-sent event with a JSON payload
+func writeSSE(w http.ResponseWriter, flusher http.Flusher, event string, payload interface{}) error {
+	data, err := json.Marshal(payload)
+	if err != nil {
+		log.Printf(utils.LogFailedEncodeRolloutStatus, err)
+		return err
+	}
+	if _, err := fmt.Fprintf(w, "event: %s\ndata: %s\n\n", event, data); err != nil {
+		return err
+	}
+	flusher.Flush()
+	return nil
+}
+
+// deploymentReplicaSets returns the ReplicaSets controlled by a deployment, newest revision first
+func deploymentReplicaSets(ctx context.Context, clientset *kubernetes.Clientset, deployment *appsv1.Deployment) ([]appsv1.ReplicaSet, error) {
+	selector, err := metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+	if err != nil {
+		return nil, err
+	}
+
+	list, err := clientset.AppsV1().ReplicaSets(deployment.Namespace).List(ctx, metav1.ListOptions{LabelSelector: selector.String()})
+	if err != nil {
+		return nil, err
+	}
+
+	owned := make([]appsv1.ReplicaSet, 0, len(list.Items))
+	for _, rs := range list.Items {
+		if ref := metav1.GetControllerOf(&rs); ref != nil && ref.UID == deployment.UID {
+			owned = append(owned, rs)
+		}
+	}
+
+	sort.Slice(owned, func(i, j int) bool {
+		return parseRevision(owned[i].Annotations[revisionAnnotation]) > parseRevision(owned[j].Annotations[revisionAnnotation])
+	})
+	return owned, nil
+}
+
+// findRevision picks the ReplicaSet for a revision; 0 means the one before current
+func findRevision(replicaSets []appsv1.ReplicaSet, current, revision int64) *appsv1.ReplicaSet {
+	for i := range replicaSets {
+		rev := parseRevision(replicaSets[i].Annotations[revisionAnnotation])
+		if revision == 0 && rev < current {
+			// Sorted newest first, so the first older revision is the previous one
+			return &replicaSets[i]
+		}
+		if revision != 0 && rev == revision {
+			return &replicaSets[i]
+		}
+	}
+	return nil
+}
+
+// templateDiff returns the strategic merge patch that turns the current template into the revision's
+func templateDiff(current, revision corev1.PodTemplateSpec) (json.RawMessage, error) {
+	revision = *revision.DeepCopy()
+	delete(revision.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+	currentJSON, err := json.Marshal(current)
+	if err != nil {
+		return nil, err
+	}
+	revisionJSON, err := json.Marshal(revision)
+	if err != nil {
+		return nil, err
+	}
+
+	patch, err := strategicpatch.CreateTwoWayMergePatch(currentJSON, revisionJSON, corev1.PodTemplateSpec{})
+	if err != nil {
+		return nil, err
+	}
+	if string(patch) == "{}" {
+		return nil, nil
+	}
+	return patch, nil
+}
+
+func parseRevision(value string) int64 {
+	revision, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		return 0
+	}
+	return revision
+}
@@ -0,0 +1,164 @@
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+// scaleAccessor reads and writes the scale subresource of one workload kind
+type scaleAccessor struct {
+	kind   string
+	get    func(ctx context.Context, namespace, name string) (*autoscalingv1.Scale, error)
+	update func(ctx context.Context, namespace string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error)
+}
+
+// scaleAccessorFor returns the scale accessor for a plural workload kind as used in routes
+func scaleAccessorFor(clientset *kubernetes.Clientset, kind string) (scaleAccessor, bool) {
+	apps := clientset.AppsV1()
+	switch kind {
+	case "deployments":
+		return scaleAccessor{
+			kind: "Deployment",
+			get: func(ctx context.Context, namespace, name string) (*autoscalingv1.Scale, error) {
+				return apps.Deployments(namespace).GetScale(ctx, name, metav1.GetOptions{})
+			},
+			update: func(ctx context.Context, namespace string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+				return apps.Deployments(namespace).UpdateScale(ctx, scale.Name, scale, metav1.UpdateOptions{})
+			},
+		}, true
+	case "statefulsets":
+		return scaleAccessor{
+			kind: "StatefulSet",
+			get: func(ctx context.Context, namespace, name string) (*autoscalingv1.Scale, error) {
+				return apps.StatefulSets(namespace).GetScale(ctx, name, metav1.GetOptions{})
+			},
+			update: func(ctx context.Context, namespace string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+				return apps.StatefulSets(namespace).UpdateScale(ctx, scale.Name, scale, metav1.UpdateOptions{})
+			},
+		}, true
+	case "replicasets":
+		return scaleAccessor{
+			kind: "ReplicaSet",
+			get: func(ctx context.Context, namespace, name string) (*autoscalingv1.Scale, error) {
+				return apps.ReplicaSets(namespace).GetScale(ctx, name, metav1.GetOptions{})
+			},
+			update: func(ctx context.Context, namespace string, scale *autoscalingv1.Scale) (*autoscalingv1.Scale, error) {
+				return apps.ReplicaSets(namespace).UpdateScale(ctx, scale.Name, scale, metav1.UpdateOptions{})
+			},
+		}, true
+	}
+	return scaleAccessor{}, false
+}
+
+// GetScale returns the current and desired replicas of a scalable workload
+func GetScale(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		accessor, ok := scaleAccessorFor(clientset, vars["kind"])
+		if !ok {
+			http.Error(w, utils.MsgUnsupportedScaleKind, http.StatusBadRequest)
+			return
+		}
+
+		scale, err := accessor.get(r.Context(), namespace, name)
+		if err != nil {
+			log.Printf(utils.LogFailedGetScale, accessor.kind, name, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgWorkloadNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedGetScale, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toScale(accessor.kind, scale)); err != nil {
+			log.Printf(utils.LogFailedEncodeScale, err)
+		}
+	}
+}
+
+// UpdateScale sets the desired replicas through the scale subresource
+func UpdateScale(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		accessor, ok := scaleAccessorFor(clientset, vars["kind"])
+		if !ok {
+			http.Error(w, utils.MsgUnsupportedScaleKind, http.StatusBadRequest)
+			return
+		}
+
+		var req models.ScaleRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+		if req.Replicas == nil {
+			writeValidationErrors(w, fieldErrors(field.ErrorList{field.Required(field.NewPath("replicas"), "")}))
+			return
+		}
+		if *req.Replicas < 0 {
+			writeValidationErrors(w, fieldErrors(field.ErrorList{
+				field.Invalid(field.NewPath("replicas"), *req.Replicas, "must be greater than or equal to 0"),
+			}))
+			return
+		}
+
+		scale := &autoscalingv1.Scale{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:            name,
+				Namespace:       namespace,
+				ResourceVersion: req.ResourceVersion,
+			},
+			Spec: autoscalingv1.ScaleSpec{Replicas: *req.Replicas},
+		}
+
+		updated, err := accessor.update(r.Context(), namespace, scale)
+		if err != nil {
+			log.Printf(utils.LogFailedUpdateScale, accessor.kind, name, err)
+			switch {
+			case apierrors.IsNotFound(err):
+				http.Error(w, utils.MsgWorkloadNotFound, http.StatusNotFound)
+			case apierrors.IsConflict(err):
+				http.Error(w, utils.MsgWorkloadModified, http.StatusConflict)
+			default:
+				http.Error(w, utils.MsgFailedUpdateScale, http.StatusInternalServerError)
+			}
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toScale(accessor.kind, updated)); err != nil {
+			log.Printf(utils.LogFailedEncodeScale, err)
+		}
+	}
+}
+
+func toScale(kind string, scale *autoscalingv1.Scale) models.Scale {
+	return models.Scale{
+		Kind:            kind,
+		Name:            scale.Name,
+		Namespace:       scale.Namespace,
+		DesiredReplicas: scale.Spec.Replicas,
+		CurrentReplicas: scale.Status.Replicas,
+		Selector:        scale.Status.Selector,
+		ResourceVersion: scale.ResourceVersion,
+	}
+}
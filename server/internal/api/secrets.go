@@ -0,0 +1,443 @@
+package api
+
+import (
+	"context"
+	"crypto/tls"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"k8_gui/internal/auth"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/gorilla/mux"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+const (
+	// auditComponent is the event source recorded for audited actions
+	auditComponent = "k8s-gui"
+
+	// defaultDockerRegistry matches the kubectl create secret docker-registry default
+	defaultDockerRegistry = "https://index.docker.io/v1/"
+)
+
+// ListSecrets returns all secrets with key names and sizes only
+func ListSecrets(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		secrets, err := clientset.CoreV1().Secrets("").List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListSecrets, err)
+			http.Error(w, utils.MsgFailedListSecrets, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.SecretListResponse{Items: make([]models.Secret, 0, len(secrets.Items))}
+		for _, s := range secrets.Items {
+			response.Items = append(response.Items, toSecret(&s))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeSecretsList, err)
+		}
+	}
+}
+
+// GetSecret returns secret details with key names and sizes only
+func GetSecret(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetSecret, err)
+			http.Error(w, utils.MsgSecretNotFound, http.StatusNotFound)
+			return
+		}
+
+		response := toSecret(secret)
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeSecret, err)
+		}
+	}
+}
+
+// RevealSecret returns decoded secret values and records who revealed them.
+// The route must be wrapped with auth.RequireSecretReveal.
+func RevealSecret(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		var req models.RevealSecretRequest
+		if err := decodeOptionalBody(r, &req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetSecret, err)
+			http.Error(w, utils.MsgSecretNotFound, http.StatusNotFound)
+			return
+		}
+
+		keys := req.Keys
+		if len(keys) == 0 {
+			keys = sortedSecretKeys(secret.Data)
+		}
+		var errs field.ErrorList
+		for i, k := range keys {
+			if _, ok := secret.Data[k]; !ok {
+				errs = append(errs, field.NotFound(field.NewPath("keys").Index(i), k))
+			}
+		}
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		username := auth.UsernameFromContext(r.Context())
+		log.Printf(utils.LogSecretRevealed, username, keys, namespace, name, r.RemoteAddr)
+		if err := recordSecretReveal(r.Context(), clientset, secret, username, keys); err != nil {
+			log.Printf(utils.LogFailedRecordSecretReveal, namespace, name, err)
+		}
+
+		response := models.RevealedSecret{
+			Name:      secret.Name,
+			Namespace: secret.Namespace,
+			Type:      string(secret.Type),
+			Values:    make([]models.SecretValue, 0, len(keys)),
+		}
+		for _, k := range keys {
+			value := secret.Data[k]
+			entry := models.SecretValue{Key: k, Value: string(value), Encoding: "text"}
+			if !utf8.Valid(value) {
+				entry.Value = base64.StdEncoding.EncodeToString(value)
+				entry.Encoding = "base64"
+			}
+			response.Values = append(response.Values, entry)
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeSecretReveal, err)
+		}
+	}
+}
+
+// CreateSecret creates a generic, docker-registry or tls secret
+func CreateSecret(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.CreateSecretRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		secret, errs := buildSecret(req)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		created, err := clientset.CoreV1().Secrets(req.Namespace).Create(r.Context(), secret, metav1.CreateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedCreateSecret, err)
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			if apierrors.IsAlreadyExists(err) {
+				http.Error(w, utils.MsgSecretAlreadyExists, http.StatusConflict)
+				return
+			}
+			http.Error(w, utils.MsgFailedCreateSecret, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(toSecret(created)); err != nil {
+			log.Printf(utils.LogFailedEncodeCreatedSecret, err)
+		}
+	}
+}
+
+// UpdateSecret sets and removes individual keys of a secret
+func UpdateSecret(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		var req models.UpdateSecretRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		var errs field.ErrorList
+		errs = append(errs, validateLabels(req.Labels, field.NewPath("labels"))...)
+		errs = append(errs, validateDataKeys(req.Data, req.BinaryData)...)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		secret, err := clientset.CoreV1().Secrets(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetSecret, err)
+			http.Error(w, utils.MsgSecretNotFound, http.StatusNotFound)
+			return
+		}
+		if req.ResourceVersion != "" && req.ResourceVersion != secret.ResourceVersion {
+			http.Error(w, utils.MsgSecretModified, http.StatusConflict)
+			return
+		}
+
+		if secret.Data == nil {
+			secret.Data = map[string][]byte{}
+		}
+		for k, v := range req.Data {
+			secret.Data[k] = []byte(v)
+		}
+		for k, v := range req.BinaryData {
+			secret.Data[k] = v
+		}
+		for i, k := range req.RemoveKeys {
+			if _, ok := secret.Data[k]; !ok {
+				errs = append(errs, field.NotFound(field.NewPath("removeKeys").Index(i), k))
+			}
+			delete(secret.Data, k)
+		}
+		if secret.Type == corev1.SecretTypeTLS {
+			errs = append(errs, validateTLSPair(secret.Data[corev1.TLSCertKey], secret.Data[corev1.TLSPrivateKeyKey], field.NewPath("data"))...)
+		}
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+		if req.Labels != nil {
+			secret.Labels = req.Labels
+		}
+
+		// The resourceVersion from the read above makes the API server reject concurrent writes
+		updated, err := clientset.CoreV1().Secrets(namespace).Update(r.Context(), secret, metav1.UpdateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedUpdateSecret, name, err)
+			if apierrors.IsConflict(err) {
+				http.Error(w, utils.MsgSecretModified, http.StatusConflict)
+				return
+			}
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			http.Error(w, utils.MsgFailedUpdateSecret, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toSecret(updated)); err != nil {
+			log.Printf(utils.LogFailedEncodeUpdatedSecret, err)
+		}
+	}
+}
+
+// DeleteSecret deletes a secret
+func DeleteSecret(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		err := clientset.CoreV1().Secrets(namespace).Delete(r.Context(), name, metav1.DeleteOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedDeleteSecret, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgSecretNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedDeleteSecret, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// buildSecret converts and validates a create request into a secret object
+func buildSecret(req models.CreateSecretRequest) (*corev1.Secret, field.ErrorList) {
+	var errs field.ErrorList
+
+	errs = append(errs, validateObjectName(req.Name, field.NewPath("name"))...)
+	errs = append(errs, validateNamespace(req.Namespace, field.NewPath("namespace"))...)
+	errs = append(errs, validateLabels(req.Labels, field.NewPath("labels"))...)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      req.Name,
+			Namespace: req.Namespace,
+			Labels:    req.Labels,
+		},
+		Data: map[string][]byte{},
+	}
+	if req.Immutable {
+		immutable := true
+		secret.Immutable = &immutable
+	}
+
+	switch req.Type {
+	case "", "generic":
+		secret.Type = corev1.SecretTypeOpaque
+		errs = append(errs, validateDataKeys(req.Data, req.BinaryData)...)
+		for k, v := range req.Data {
+			secret.Data[k] = []byte(v)
+		}
+		for k, v := range req.BinaryData {
+			secret.Data[k] = v
+		}
+
+	case "docker-registry":
+		secret.Type = corev1.SecretTypeDockerConfigJson
+		if req.Username == "" {
+			errs = append(errs, field.Required(field.NewPath("username"), "username is required"))
+		}
+		if req.Password == "" {
+			errs = append(errs, field.Required(field.NewPath("password"), "password is required"))
+		}
+		registry := req.Registry
+		if registry == "" {
+			registry = defaultDockerRegistry
+		}
+		config, err := dockerConfigJSON(registry, req.Username, req.Password, req.Email)
+		if err != nil {
+			errs = append(errs, field.InternalError(field.NewPath("registry"), err))
+		}
+		secret.Data[corev1.DockerConfigJsonKey] = config
+
+	case "tls":
+		secret.Type = corev1.SecretTypeTLS
+		errs = append(errs, validateTLSPair([]byte(req.Cert), []byte(req.Key), nil)...)
+		secret.Data[corev1.TLSCertKey] = []byte(req.Cert)
+		secret.Data[corev1.TLSPrivateKeyKey] = []byte(req.Key)
+
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("type"), req.Type, []string{"generic", "docker-registry", "tls"}))
+	}
+
+	return secret, errs
+}
+
+// dockerConfigJSON builds the .dockerconfigjson payload kubectl create secret docker-registry writes
+func dockerConfigJSON(registry, username, password, email string) ([]byte, error) {
+	entry := map[string]string{
+		"username": username,
+		"password": password,
+		"auth":     base64.StdEncoding.EncodeToString([]byte(username + ":" + password)),
+	}
+	if email != "" {
+		entry["email"] = email
+	}
+	return json.Marshal(map[string]interface{}{
+		"auths": map[string]interface{}{registry: entry},
+	})
+}
+
+// validateTLSPair checks the certificate and key are valid PEM and belong together.
+// Values are omitted from errors so key material is never echoed back.
+func validateTLSPair(cert, key []byte, fldPath *field.Path) field.ErrorList {
+	certPath, keyPath := field.NewPath("cert"), field.NewPath("key")
+	if fldPath != nil {
+		certPath, keyPath = fldPath.Key(corev1.TLSCertKey), fldPath.Key(corev1.TLSPrivateKeyKey)
+	}
+
+	var errs field.ErrorList
+	if len(cert) == 0 {
+		errs = append(errs, field.Required(certPath, "PEM-encoded certificate is required"))
+	}
+	if len(key) == 0 {
+		errs = append(errs, field.Required(keyPath, "PEM-encoded private key is required"))
+	}
+	if len(errs) > 0 {
+		return errs
+	}
+	if _, err := tls.X509KeyPair(cert, key); err != nil {
+		errs = append(errs, field.Invalid(certPath, field.OmitValueType{}, err.Error()))
+	}
+	return errs
+}
+
+// recordSecretReveal leaves an event on the secret so reveals show up in the cluster's audit trail
+func recordSecretReveal(ctx context.Context, clientset *kubernetes.Clientset, secret *corev1.Secret, username string, keys []string) error {
+	now := metav1.NewTime(time.Now())
+	event := &corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: secret.Name + ".",
+			Namespace:    secret.Namespace,
+		},
+		InvolvedObject: corev1.ObjectReference{
+			Kind:            "Secret",
+			APIVersion:      "v1",
+			Namespace:       secret.Namespace,
+			Name:            secret.Name,
+			UID:             secret.UID,
+			ResourceVersion: secret.ResourceVersion,
+		},
+		Reason:         "SecretRevealed",
+		Message:        fmt.Sprintf("User %q revealed keys: %s", username, strings.Join(keys, ", ")),
+		Type:           corev1.EventTypeNormal,
+		Source:         corev1.EventSource{Component: auditComponent},
+		FirstTimestamp: now,
+		LastTimestamp:  now,
+		Count:          1,
+	}
+	_, err := clientset.CoreV1().Events(secret.Namespace).Create(ctx, event, metav1.CreateOptions{})
+	return err
+}
+
+func sortedSecretKeys(data map[string][]byte) []string {
+	keys := make([]string, 0, len(data))
+	for k := range data {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// toSecret converts a secret into the simplified view without any values
+func toSecret(s *corev1.Secret) models.Secret {
+	keys := make([]models.SecretKey, 0, len(s.Data))
+	for _, k := range sortedSecretKeys(s.Data) {
+		keys = append(keys, models.SecretKey{Name: k, Size: len(s.Data[k])})
+	}
+
+	return models.Secret{
+		Name:            s.Name,
+		Namespace:       s.Namespace,
+		Type:            string(s.Type),
+		Keys:            keys,
+		Immutable:       s.Immutable != nil && *s.Immutable,
+		ResourceVersion: s.ResourceVersion,
+		CreatedAt:       s.CreationTimestamp.Time.Format(time.RFC3339),
+		Labels:          s.Labels,
+	}
+}
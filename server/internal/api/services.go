@@ -2,18 +2,36 @@ package api
 
 import (
 	"encoding/json"
+	"fmt"
 	"k8_gui/internal/models"
 	"k8_gui/internal/utils"
 	"log"
 	"net/http"
+	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gorilla/mux"
 	corev1 "k8s.io/api/core/v1"
+	discoveryv1 "k8s.io/api/discovery/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
 	"k8s.io/client-go/kubernetes"
 )
 
+// Warnings reported for services whose selector leaves them without backends
+const (
+	serviceWarningNoMatchingPods = "NoMatchingPods"
+	serviceWarningNoReadyPods    = "NoReadyPods"
+)
+
+// maxSessionAffinitySeconds is the API server's upper bound for ClientIP affinity timeouts
+const maxSessionAffinitySeconds = 86400
+
 // ListServices returns all services
 func ListServices(clientset *kubernetes.Clientset) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -24,24 +42,19 @@ func ListServices(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
+		// Pod health is best effort; the list is still useful without it
+		pods, err := clientset.CoreV1().Pods("").List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListServicePods, err)
+		}
+
 		response := models.ServiceListResponse{Items: make([]models.Service, 0, len(services.Items))}
-		for _, s := range services.Items {
-			ports := make([]models.ServicePort, len(s.Spec.Ports))
-			for i, p := range s.Spec.Ports {
-				ports[i] = models.ServicePort{
-					Port:     p.Port,
-					Protocol: string(p.Protocol),
-				}
+		for i := range services.Items {
+			service := toService(&services.Items[i])
+			if pods != nil {
+				setServicePodHealth(&service, &services.Items[i], pods.Items)
 			}
-
-			response.Items = append(response.Items, models.Service{
-				Name:      s.Name,
-				Namespace: s.Namespace,
-				Type:      string(s.Spec.Type),
-				ClusterIP: s.Spec.ClusterIP,
-				Ports:     ports,
-				CreatedAt: s.CreationTimestamp.Time.Format(time.RFC3339),
-			})
+			response.Items = append(response.Items, service)
 		}
 
 		w.Header().Set("Content-Type", "application/json")
@@ -65,26 +78,71 @@ func GetService(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
-		ports := make([]models.ServicePort, len(service.Spec.Ports))
-		for i, p := range service.Spec.Ports {
-			ports[i] = models.ServicePort{
-				Port:     p.Port,
-				Protocol: string(p.Protocol),
+		response := toService(service)
+		if len(service.Spec.Selector) > 0 {
+			pods, err := clientset.CoreV1().Pods(namespace).List(r.Context(), metav1.ListOptions{
+				LabelSelector: labels.SelectorFromSet(service.Spec.Selector).String(),
+			})
+			if err != nil {
+				log.Printf(utils.LogFailedListServicePods, err)
+			} else {
+				setServicePodHealth(&response, service, pods.Items)
 			}
 		}
 
-		response := models.Service{
-			Name:      service.Name,
-			Namespace: service.Namespace,
-			Type:      string(service.Spec.Type),
-			ClusterIP: service.Spec.ClusterIP,
-			Ports:     ports,
-			CreatedAt: service.CreationTimestamp.Time.Format(time.RFC3339),
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeService, err)
+		}
+	}
+}
+
+// GetServiceEndpoints returns the EndpointSlices of a service with addresses split into ready and not ready
+func GetServiceEndpoints(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		service, err := clientset.CoreV1().Services(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetService, err)
+			http.Error(w, utils.MsgServiceNotFound, http.StatusNotFound)
+			return
+		}
+
+		slices, err := clientset.DiscoveryV1().EndpointSlices(namespace).List(r.Context(), metav1.ListOptions{
+			LabelSelector: discoveryv1.LabelServiceName + "=" + name,
+		})
+		if err != nil {
+			log.Printf(utils.LogFailedListEndpointSlices, name, err)
+			http.Error(w, utils.MsgFailedListEndpointSlices, http.StatusInternalServerError)
+			return
+		}
+		sort.Slice(slices.Items, func(i, j int) bool { return slices.Items[i].Name < slices.Items[j].Name })
+
+		response := models.ServiceEndpointsResponse{
+			Service:   name,
+			Namespace: namespace,
+			Slices:    make([]models.ServiceEndpointSlice, 0, len(slices.Items)),
+		}
+		for i := range slices.Items {
+			slice := toServiceEndpointSlice(&slices.Items[i])
+			response.ReadyCount += len(slice.Ready)
+			response.NotReadyCount += len(slice.NotReady)
+			response.Slices = append(response.Slices, slice)
+		}
+		if service.Spec.Type != corev1.ServiceTypeExternalName && len(service.Spec.Selector) > 0 {
+			if response.ReadyCount+response.NotReadyCount == 0 {
+				response.Warning = serviceWarningNoMatchingPods
+			} else if response.ReadyCount == 0 {
+				response.Warning = serviceWarningNoReadyPods
+			}
 		}
 
 		w.Header().Set("Content-Type", "application/json")
 		if err := json.NewEncoder(w).Encode(response); err != nil {
-			log.Printf(utils.LogFailedEncodeService, err)
+			log.Printf(utils.LogFailedEncodeServiceEndpoints, err)
 		}
 	}
 }
@@ -98,29 +156,50 @@ func CreateService(clientset *kubernetes.Clientset) http.HandlerFunc {
 			return
 		}
 
+		var errs field.ErrorList
+		errs = append(errs, validateObjectName(req.Name, field.NewPath("name"))...)
+		errs = append(errs, validateNamespace(req.Namespace, field.NewPath("namespace"))...)
+
+		spec := req.ServiceSpec
+		if len(spec.Ports) == 0 && req.Port > 0 {
+			port := models.ServicePortSpec{Port: req.Port}
+			if req.TargetPort > 0 {
+				port.TargetPort = strconv.Itoa(int(req.TargetPort))
+			}
+			spec.Ports = []models.ServicePortSpec{port}
+		}
+		if spec.Selector == nil && spec.Type != string(corev1.ServiceTypeExternalName) {
+			spec.Selector = map[string]string{"app": req.Name}
+		}
+
+		serviceSpec, sErrs := buildServiceSpec(spec)
+		errs = append(errs, sErrs...)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
 		service := &corev1.Service{
 			ObjectMeta: metav1.ObjectMeta{
-				Name:      req.Name,
-				Namespace: req.Namespace,
-			},
-			Spec: corev1.ServiceSpec{
-				Selector: map[string]string{
-					"app": req.Name,
-				},
-				Ports: []corev1.ServicePort{
-					{
-						Port:       req.Port,
-						TargetPort: utils.IntstrFromInt(req.TargetPort),
-						Protocol:   corev1.ProtocolTCP,
-					},
-				},
-				Type: corev1.ServiceTypeClusterIP,
+				Name:        req.Name,
+				Namespace:   req.Namespace,
+				Labels:      spec.Labels,
+				Annotations: spec.Annotations,
 			},
+			Spec: serviceSpec,
 		}
 
 		created, err := clientset.CoreV1().Services(req.Namespace).Create(r.Context(), service, metav1.CreateOptions{})
 		if err != nil {
 			log.Printf(utils.LogFailedCreateService, err)
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			if apierrors.IsAlreadyExists(err) {
+				http.Error(w, utils.MsgServiceAlreadyExists, http.StatusConflict)
+				return
+			}
 			http.Error(w, utils.MsgFailedCreateService, http.StatusInternalServerError)
 			return
 		}
@@ -133,6 +212,74 @@ func CreateService(clientset *kubernetes.Clientset) http.HandlerFunc {
 	}
 }
 
+// UpdateService replaces the spec of a service, keeping its allocated cluster IP and node ports
+func UpdateService(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		var req models.UpdateServiceRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		service, err := clientset.CoreV1().Services(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetService, err)
+			http.Error(w, utils.MsgServiceNotFound, http.StatusNotFound)
+			return
+		}
+		if req.ResourceVersion != "" && req.ResourceVersion != service.ResourceVersion {
+			http.Error(w, utils.MsgServiceModified, http.StatusConflict)
+			return
+		}
+
+		spec := req.ServiceSpec
+		if spec.Selector == nil && spec.Type != string(corev1.ServiceTypeExternalName) {
+			spec.Selector = service.Spec.Selector
+		}
+
+		serviceSpec, errs := buildServiceSpec(spec)
+		errs = append(errs, validateHeadlessChange(&serviceSpec, &service.Spec)...)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+		preserveServiceAllocations(&serviceSpec, &service.Spec)
+
+		service.Spec = serviceSpec
+		if spec.Labels != nil {
+			service.Labels = spec.Labels
+		}
+		if spec.Annotations != nil {
+			service.Annotations = spec.Annotations
+		}
+
+		// The resourceVersion from the read above makes the API server reject concurrent writes
+		updated, err := clientset.CoreV1().Services(namespace).Update(r.Context(), service, metav1.UpdateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedUpdateService, name, err)
+			if apierrors.IsConflict(err) {
+				http.Error(w, utils.MsgServiceModified, http.StatusConflict)
+				return
+			}
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			http.Error(w, utils.MsgFailedUpdateService, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toService(updated)); err != nil {
+			log.Printf(utils.LogFailedEncodeUpdatedService, err)
+		}
+	}
+}
+
 // DeleteService deletes a service
 func DeleteService(clientset *kubernetes.Clientset) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
@@ -143,6 +290,10 @@ func DeleteService(clientset *kubernetes.Clientset) http.HandlerFunc {
 		err := clientset.CoreV1().Services(namespace).Delete(r.Context(), name, metav1.DeleteOptions{})
 		if err != nil {
 			log.Printf(utils.LogFailedDeleteService, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgServiceNotFound, http.StatusNotFound)
+				return
+			}
 			http.Error(w, utils.MsgFailedDeleteService, http.StatusInternalServerError)
 			return
 		}
@@ -150,3 +301,300 @@ func DeleteService(clientset *kubernetes.Clientset) http.HandlerFunc {
 		w.WriteHeader(http.StatusNoContent)
 	}
 }
+
+// buildServiceSpec converts and validates the editable parts of a service
+func buildServiceSpec(spec models.ServiceSpec) (corev1.ServiceSpec, field.ErrorList) {
+	var errs field.ErrorList
+
+	serviceType := corev1.ServiceType(spec.Type)
+	switch serviceType {
+	case "":
+		serviceType = corev1.ServiceTypeClusterIP
+	case corev1.ServiceTypeClusterIP, corev1.ServiceTypeNodePort, corev1.ServiceTypeLoadBalancer, corev1.ServiceTypeExternalName:
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("type"), spec.Type, []string{"ClusterIP", "NodePort", "LoadBalancer", "ExternalName"}))
+	}
+	result := corev1.ServiceSpec{Type: serviceType}
+	exposesNodePorts := serviceType == corev1.ServiceTypeNodePort || serviceType == corev1.ServiceTypeLoadBalancer
+
+	if spec.Headless {
+		if serviceType != corev1.ServiceTypeClusterIP {
+			errs = append(errs, field.Forbidden(field.NewPath("headless"), "headless is only allowed for ClusterIP services"))
+		}
+		result.ClusterIP = corev1.ClusterIPNone
+	}
+
+	if serviceType == corev1.ServiceTypeExternalName {
+		if spec.ExternalName == "" {
+			errs = append(errs, field.Required(field.NewPath("externalName"), "externalName is required for ExternalName services"))
+		}
+		if len(spec.Selector) > 0 {
+			errs = append(errs, field.Forbidden(field.NewPath("selector"), "selector is not allowed for ExternalName services"))
+		}
+	} else if spec.ExternalName != "" {
+		errs = append(errs, field.Forbidden(field.NewPath("externalName"), "externalName is only allowed for ExternalName services"))
+	}
+	if spec.ExternalName != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(strings.TrimSuffix(spec.ExternalName, ".")) {
+			errs = append(errs, field.Invalid(field.NewPath("externalName"), spec.ExternalName, msg))
+		}
+		result.ExternalName = spec.ExternalName
+	}
+
+	errs = append(errs, validateLabels(spec.Selector, field.NewPath("selector"))...)
+	errs = append(errs, validateLabels(spec.Labels, field.NewPath("labels"))...)
+	result.Selector = spec.Selector
+
+	if len(spec.Ports) == 0 && serviceType != corev1.ServiceTypeExternalName && !spec.Headless {
+		errs = append(errs, field.Required(field.NewPath("ports"), "at least one port is required"))
+	}
+	portNames := make(map[string]bool, len(spec.Ports))
+	for i, p := range spec.Ports {
+		port, pErrs := buildServicePort(p, len(spec.Ports) > 1, exposesNodePorts, field.NewPath("ports").Index(i))
+		errs = append(errs, pErrs...)
+		if p.Name != "" {
+			if portNames[p.Name] {
+				errs = append(errs, field.Duplicate(field.NewPath("ports").Index(i).Child("name"), p.Name))
+			}
+			portNames[p.Name] = true
+		}
+		result.Ports = append(result.Ports, port)
+	}
+
+	switch affinity := corev1.ServiceAffinity(spec.SessionAffinity); affinity {
+	case "", corev1.ServiceAffinityNone:
+		if spec.SessionAffinityTimeoutSeconds != nil {
+			errs = append(errs, field.Forbidden(field.NewPath("sessionAffinityTimeoutSeconds"), "only allowed with ClientIP session affinity"))
+		}
+	case corev1.ServiceAffinityClientIP:
+		result.SessionAffinity = affinity
+		if t := spec.SessionAffinityTimeoutSeconds; t != nil {
+			if *t <= 0 || *t > maxSessionAffinitySeconds {
+				errs = append(errs, field.Invalid(field.NewPath("sessionAffinityTimeoutSeconds"), *t,
+					fmt.Sprintf("must be between 1 and %d", maxSessionAffinitySeconds)))
+			}
+			result.SessionAffinityConfig = &corev1.SessionAffinityConfig{
+				ClientIP: &corev1.ClientIPConfig{TimeoutSeconds: t},
+			}
+		}
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("sessionAffinity"), spec.SessionAffinity, []string{"None", "ClientIP"}))
+	}
+
+	switch policy := corev1.ServiceExternalTrafficPolicy(spec.ExternalTrafficPolicy); policy {
+	case "":
+	case corev1.ServiceExternalTrafficPolicyCluster, corev1.ServiceExternalTrafficPolicyLocal:
+		if !exposesNodePorts {
+			errs = append(errs, field.Forbidden(field.NewPath("externalTrafficPolicy"), "only allowed for NodePort and LoadBalancer services"))
+		}
+		result.ExternalTrafficPolicy = policy
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("externalTrafficPolicy"), spec.ExternalTrafficPolicy, []string{"Cluster", "Local"}))
+	}
+
+	return result, errs
+}
+
+// buildServicePort converts a port; names are required once a service has several ports
+func buildServicePort(spec models.ServicePortSpec, multiple, exposesNodePorts bool, fldPath *field.Path) (corev1.ServicePort, field.ErrorList) {
+	var errs field.ErrorList
+
+	if spec.Name == "" && multiple {
+		errs = append(errs, field.Required(fldPath.Child("name"), "name is required when a service has more than one port"))
+	}
+	if spec.Name != "" {
+		for _, msg := range validation.IsDNS1123Label(spec.Name) {
+			errs = append(errs, field.Invalid(fldPath.Child("name"), spec.Name, msg))
+		}
+	}
+	for _, msg := range validation.IsValidPortNum(int(spec.Port)) {
+		errs = append(errs, field.Invalid(fldPath.Child("port"), spec.Port, msg))
+	}
+
+	protocol, pErrs := parseProtocol(spec.Protocol, fldPath.Child("protocol"))
+	errs = append(errs, pErrs...)
+
+	port := corev1.ServicePort{
+		Name:       spec.Name,
+		Port:       spec.Port,
+		Protocol:   protocol,
+		TargetPort: utils.IntstrFromInt(spec.Port),
+	}
+	if spec.TargetPort != "" {
+		target, tErrs := parsePortRef(spec.TargetPort, fldPath.Child("targetPort"))
+		errs = append(errs, tErrs...)
+		port.TargetPort = target
+	}
+
+	if spec.NodePort != 0 {
+		if !exposesNodePorts {
+			errs = append(errs, field.Forbidden(fldPath.Child("nodePort"), "only allowed for NodePort and LoadBalancer services"))
+		}
+		for _, msg := range validation.IsValidPortNum(int(spec.NodePort)) {
+			errs = append(errs, field.Invalid(fldPath.Child("nodePort"), spec.NodePort, msg))
+		}
+		port.NodePort = spec.NodePort
+	}
+
+	return port, errs
+}
+
+// preserveServiceAllocations carries the cluster IP, node ports and health check port of the current
+// spec over to a replacement so an update does not reallocate them
+func preserveServiceAllocations(spec, current *corev1.ServiceSpec) {
+	if spec.Type != corev1.ServiceTypeExternalName && current.Type != corev1.ServiceTypeExternalName &&
+		spec.ClusterIP == "" && current.ClusterIP != corev1.ClusterIPNone {
+		spec.ClusterIP = current.ClusterIP
+		spec.ClusterIPs = current.ClusterIPs
+		spec.IPFamilies = current.IPFamilies
+		spec.IPFamilyPolicy = current.IPFamilyPolicy
+	}
+
+	if spec.Type == corev1.ServiceTypeNodePort || spec.Type == corev1.ServiceTypeLoadBalancer {
+		for i := range spec.Ports {
+			if spec.Ports[i].NodePort != 0 {
+				continue
+			}
+			for _, old := range current.Ports {
+				if old.NodePort != 0 && old.Protocol == spec.Ports[i].Protocol &&
+					(old.Name == spec.Ports[i].Name || old.Port == spec.Ports[i].Port) {
+					spec.Ports[i].NodePort = old.NodePort
+					break
+				}
+			}
+		}
+	}
+
+	if spec.Type == corev1.ServiceTypeLoadBalancer && spec.ExternalTrafficPolicy == corev1.ServiceExternalTrafficPolicyLocal {
+		spec.HealthCheckNodePort = current.HealthCheckNodePort
+	}
+}
+
+// validateHeadlessChange rejects toggling headless on a service that keeps its cluster IP allocation,
+// since the API server treats clusterIP as immutable. Changes to or from ExternalName are allowed.
+func validateHeadlessChange(spec, current *corev1.ServiceSpec) field.ErrorList {
+	if spec.Type == corev1.ServiceTypeExternalName || current.Type == corev1.ServiceTypeExternalName {
+		return nil
+	}
+	headless := spec.ClusterIP == corev1.ClusterIPNone
+	if headless == (current.ClusterIP == corev1.ClusterIPNone) {
+		return nil
+	}
+	return field.ErrorList{field.Invalid(field.NewPath("headless"), headless, "field is immutable; recreate the service to change it")}
+}
+
+// setServicePodHealth counts the pods selected by a service and flags services left without backends
+func setServicePodHealth(service *models.Service, svc *corev1.Service, pods []corev1.Pod) {
+	if svc.Spec.Type == corev1.ServiceTypeExternalName || len(svc.Spec.Selector) == 0 {
+		return
+	}
+
+	matched := servicePods(svc, pods)
+	ready := 0
+	for _, pod := range matched {
+		if pod.Ready {
+			ready++
+		}
+	}
+	matchedCount := len(matched)
+	service.MatchedPods = &matchedCount
+	service.ReadyPods = &ready
+
+	switch {
+	case matchedCount == 0:
+		service.Warning = serviceWarningNoMatchingPods
+	case ready == 0:
+		service.Warning = serviceWarningNoReadyPods
+	}
+}
+
+// toServiceEndpointSlice converts an EndpointSlice, mapping endpoints to their pods and nodes
+func toServiceEndpointSlice(slice *discoveryv1.EndpointSlice) models.ServiceEndpointSlice {
+	result := models.ServiceEndpointSlice{
+		Name:        slice.Name,
+		AddressType: string(slice.AddressType),
+		Ports:       make([]models.ServicePort, 0, len(slice.Ports)),
+		Ready:       []models.EndpointAddress{},
+		NotReady:    []models.EndpointAddress{},
+	}
+	for _, p := range slice.Ports {
+		port := models.ServicePort{}
+		if p.Name != nil {
+			port.Name = *p.Name
+		}
+		if p.Port != nil {
+			port.Port = *p.Port
+		}
+		if p.Protocol != nil {
+			port.Protocol = string(*p.Protocol)
+		}
+		result.Ports = append(result.Ports, port)
+	}
+
+	for _, e := range slice.Endpoints {
+		address := models.EndpointAddress{
+			Addresses: e.Addresses,
+			// A nil condition means unknown, which consumers treat as ready
+			Serving:     e.Conditions.Serving == nil || *e.Conditions.Serving,
+			Terminating: e.Conditions.Terminating != nil && *e.Conditions.Terminating,
+		}
+		if e.TargetRef != nil && e.TargetRef.Kind == "Pod" {
+			address.PodName = e.TargetRef.Name
+		}
+		if e.NodeName != nil {
+			address.NodeName = *e.NodeName
+		}
+		if e.Zone != nil {
+			address.Zone = *e.Zone
+		}
+		if e.Hostname != nil {
+			address.Hostname = *e.Hostname
+		}
+
+		if e.Conditions.Ready == nil || *e.Conditions.Ready {
+			result.Ready = append(result.Ready, address)
+		} else {
+			result.NotReady = append(result.NotReady, address)
+		}
+	}
+	return result
+}
+
+// toService converts a service into the simplified view
+func toService(s *corev1.Service) models.Service {
+	ports := make([]models.ServicePort, len(s.Spec.Ports))
+	for i, p := range s.Spec.Ports {
+		ports[i] = models.ServicePort{
+			Name:       p.Name,
+			Port:       p.Port,
+			TargetPort: p.TargetPort.String(),
+			NodePort:   p.NodePort,
+			Protocol:   string(p.Protocol),
+		}
+	}
+
+	service := models.Service{
+		Name:                  s.Name,
+		Namespace:             s.Namespace,
+		Type:                  string(s.Spec.Type),
+		ClusterIP:             s.Spec.ClusterIP,
+		Headless:              s.Spec.ClusterIP == corev1.ClusterIPNone,
+		ExternalName:          s.Spec.ExternalName,
+		Ports:                 ports,
+		Selector:              s.Spec.Selector,
+		SessionAffinity:       string(s.Spec.SessionAffinity),
+		ExternalTrafficPolicy: string(s.Spec.ExternalTrafficPolicy),
+		ResourceVersion:       s.ResourceVersion,
+		CreatedAt:             s.CreationTimestamp.Time.Format(time.RFC3339),
+		Labels:                s.Labels,
+	}
+	for _, lb := range s.Status.LoadBalancer.Ingress {
+		if lb.IP != "" {
+			service.LoadBalancerAddresses = append(service.LoadBalancerAddresses, lb.IP)
+		}
+		if lb.Hostname != "" {
+			service.LoadBalancerAddresses = append(service.LoadBalancerAddresses, lb.Hostname)
+		}
+	}
+	return service
+}
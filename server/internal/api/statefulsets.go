@@ -0,0 +1,448 @@
+package api
+
+import (
+	"encoding/json"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"k8s.io/client-go/kubernetes"
+)
+
+// ListStatefulSets returns all statefulsets
+func ListStatefulSets(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		statefulSets, err := clientset.AppsV1().StatefulSets("").List(r.Context(), metav1.ListOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedListStatefulSets, err)
+			http.Error(w, utils.MsgFailedListStatefulSets, http.StatusInternalServerError)
+			return
+		}
+
+		response := models.StatefulSetListResponse{Items: make([]models.StatefulSet, 0, len(statefulSets.Items))}
+		for _, s := range statefulSets.Items {
+			response.Items = append(response.Items, toStatefulSet(&s))
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeStatefulSetsList, err)
+		}
+	}
+}
+
+// GetStatefulSet returns statefulset details including the readiness of each ordinal
+func GetStatefulSet(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		statefulSet, err := clientset.AppsV1().StatefulSets(namespace).Get(r.Context(), name, metav1.GetOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedGetStatefulSet, err)
+			http.Error(w, utils.MsgStatefulSetNotFound, http.StatusNotFound)
+			return
+		}
+
+		response := toStatefulSet(statefulSet)
+
+		selector, err := metav1.LabelSelectorAsSelector(statefulSet.Spec.Selector)
+		if err == nil {
+			pods, err := clientset.CoreV1().Pods(namespace).List(r.Context(), metav1.ListOptions{LabelSelector: selector.String()})
+			if err != nil {
+				log.Printf(utils.LogFailedListStatefulSetPods, name, err)
+			} else {
+				response.Ordinals = statefulSetOrdinals(statefulSet, pods.Items)
+			}
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(response); err != nil {
+			log.Printf(utils.LogFailedEncodeStatefulSet, err)
+		}
+	}
+}
+
+// CreateStatefulSet creates a new statefulset
+func CreateStatefulSet(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req models.CreateStatefulSetRequest
+		if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+			http.Error(w, utils.MsgInvalidRequestBody, http.StatusBadRequest)
+			return
+		}
+
+		statefulSet, errs := buildStatefulSet(req)
+		if len(errs) > 0 {
+			writeValidationErrors(w, fieldErrors(errs))
+			return
+		}
+
+		created, err := clientset.AppsV1().StatefulSets(req.Namespace).Create(r.Context(), statefulSet, metav1.CreateOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedCreateStatefulSet, err)
+			if errs, ok := apiValidationErrors(err); ok {
+				writeValidationErrors(w, errs)
+				return
+			}
+			if apierrors.IsAlreadyExists(err) {
+				http.Error(w, utils.MsgStatefulSetAlreadyExists, http.StatusConflict)
+				return
+			}
+			http.Error(w, utils.MsgFailedCreateStatefulSet, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusCreated)
+		if err := json.NewEncoder(w).Encode(toStatefulSet(created)); err != nil {
+			log.Printf(utils.LogFailedEncodeCreatedStatefulSet, err)
+		}
+	}
+}
+
+// DeleteStatefulSet deletes a statefulset. Volume claims created from its templates are kept.
+func DeleteStatefulSet(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		opts, err := parseDeleteOptions(r)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+
+		err = clientset.AppsV1().StatefulSets(namespace).Delete(r.Context(), name, opts)
+		if err != nil {
+			log.Printf(utils.LogFailedDeleteStatefulSet, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgStatefulSetNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedDeleteStatefulSet, http.StatusInternalServerError)
+			return
+		}
+
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+// RestartStatefulSet triggers a rolling restart the same way kubectl rollout restart does
+func RestartStatefulSet(clientset *kubernetes.Clientset) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+		namespace := vars["namespace"]
+		name := vars["name"]
+
+		patch := map[string]interface{}{
+			"spec": map[string]interface{}{
+				"template": map[string]interface{}{
+					"metadata": map[string]interface{}{
+						"annotations": map[string]string{
+							restartedAtAnnotation: time.Now().Format(time.RFC3339),
+						},
+					},
+				},
+			},
+		}
+		data, err := json.Marshal(patch)
+		if err != nil {
+			log.Printf(utils.LogFailedPatchStatefulSet, name, err)
+			http.Error(w, utils.MsgFailedUpdateStatefulSet, http.StatusInternalServerError)
+			return
+		}
+
+		patched, err := clientset.AppsV1().StatefulSets(namespace).Patch(r.Context(), name, types.StrategicMergePatchType, data, metav1.PatchOptions{})
+		if err != nil {
+			log.Printf(utils.LogFailedPatchStatefulSet, name, err)
+			if apierrors.IsNotFound(err) {
+				http.Error(w, utils.MsgStatefulSetNotFound, http.StatusNotFound)
+				return
+			}
+			http.Error(w, utils.MsgFailedUpdateStatefulSet, http.StatusInternalServerError)
+			return
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		if err := json.NewEncoder(w).Encode(toStatefulSet(patched)); err != nil {
+			log.Printf(utils.LogFailedEncodeUpdatedStatefulSet, err)
+		}
+	}
+}
+
+// buildStatefulSet converts and validates a create request into a statefulset object
+func buildStatefulSet(req models.CreateStatefulSetRequest) (*appsv1.StatefulSet, field.ErrorList) {
+	var errs field.ErrorList
+
+	errs = append(errs, validateObjectName(req.Name, field.NewPath("name"))...)
+	errs = append(errs, validateNamespace(req.Namespace, field.NewPath("namespace"))...)
+	if req.Replicas < 0 {
+		errs = append(errs, field.Invalid(field.NewPath("replicas"), req.Replicas, "must be greater than or equal to 0"))
+	}
+	if req.ServiceName != "" {
+		for _, msg := range validation.IsDNS1123Label(req.ServiceName) {
+			errs = append(errs, field.Invalid(field.NewPath("serviceName"), req.ServiceName, msg))
+		}
+	}
+
+	labels := req.Labels
+	if len(labels) == 0 {
+		labels = map[string]string{"app": req.Name}
+	}
+	selector := req.Selector
+	if len(selector) == 0 {
+		selector = labels
+	}
+	errs = append(errs, validateLabels(labels, field.NewPath("labels"))...)
+	errs = append(errs, validateLabels(selector, field.NewPath("selector"))...)
+	for k, v := range selector {
+		if labels[k] != v {
+			errs = append(errs, field.Invalid(field.NewPath("selector").Key(k), v, "selector must match the pod template labels"))
+		}
+	}
+
+	claims := make([]corev1.PersistentVolumeClaim, 0, len(req.VolumeClaimTemplates))
+	claimNames := make([]string, 0, len(req.VolumeClaimTemplates))
+	seen := make(map[string]bool, len(req.VolumeClaimTemplates))
+	for i, t := range req.VolumeClaimTemplates {
+		idxPath := field.NewPath("volumeClaimTemplates").Index(i)
+		if seen[t.Name] {
+			errs = append(errs, field.Duplicate(idxPath.Child("name"), t.Name))
+		}
+		seen[t.Name] = true
+
+		claim, cErrs := buildVolumeClaimTemplate(t, idxPath)
+		errs = append(errs, cErrs...)
+		claims = append(claims, claim)
+		claimNames = append(claimNames, t.Name)
+	}
+
+	template, tErrs := buildPodTemplate(labels, nil, req.PodTemplateOptions, claimNames, nil)
+	errs = append(errs, tErrs...)
+
+	replicas := req.Replicas
+	statefulSet := &appsv1.StatefulSet{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        req.Name,
+			Namespace:   req.Namespace,
+			Labels:      labels,
+			Annotations: req.Annotations,
+		},
+		Spec: appsv1.StatefulSetSpec{
+			Replicas:             &replicas,
+			Selector:             &metav1.LabelSelector{MatchLabels: selector},
+			Template:             template,
+			ServiceName:          req.ServiceName,
+			VolumeClaimTemplates: claims,
+		},
+	}
+
+	switch policy := appsv1.PodManagementPolicyType(req.PodManagementPolicy); policy {
+	case "":
+	case appsv1.OrderedReadyPodManagement, appsv1.ParallelPodManagement:
+		statefulSet.Spec.PodManagementPolicy = policy
+	default:
+		errs = append(errs, field.NotSupported(field.NewPath("podManagementPolicy"), req.PodManagementPolicy, []string{"OrderedReady", "Parallel"}))
+	}
+
+	if req.UpdateStrategy != nil {
+		strategy, sErrs := buildStatefulSetStrategy(*req.UpdateStrategy, field.NewPath("updateStrategy"))
+		errs = append(errs, sErrs...)
+		statefulSet.Spec.UpdateStrategy = strategy
+	}
+
+	return statefulSet, errs
+}
+
+func buildStatefulSetStrategy(spec models.StatefulSetStrategy, fldPath *field.Path) (appsv1.StatefulSetUpdateStrategy, field.ErrorList) {
+	var errs field.ErrorList
+	strategy := appsv1.StatefulSetUpdateStrategy{Type: appsv1.StatefulSetUpdateStrategyType(spec.Type)}
+
+	switch strategy.Type {
+	case appsv1.OnDeleteStatefulSetStrategyType:
+		if spec.Partition != nil {
+			errs = append(errs, field.Forbidden(fldPath.Child("partition"), "partition is only allowed with RollingUpdate"))
+		}
+	case "", appsv1.RollingUpdateStatefulSetStrategyType:
+		strategy.Type = appsv1.RollingUpdateStatefulSetStrategyType
+		if spec.Partition != nil {
+			if *spec.Partition < 0 {
+				errs = append(errs, field.Invalid(fldPath.Child("partition"), *spec.Partition, "must be greater than or equal to 0"))
+			}
+			strategy.RollingUpdate = &appsv1.RollingUpdateStatefulSetStrategy{Partition: spec.Partition}
+		}
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("type"), spec.Type, []string{"RollingUpdate", "OnDelete"}))
+	}
+	return strategy, errs
+}
+
+// buildVolumeClaimTemplate converts a claim template; access modes default to ReadWriteOnce
+func buildVolumeClaimTemplate(spec models.VolumeClaimTemplate, fldPath *field.Path) (corev1.PersistentVolumeClaim, field.ErrorList) {
+	var errs field.ErrorList
+
+	errs = append(errs, validateObjectName(spec.Name, fldPath.Child("name"))...)
+
+	claim := corev1.PersistentVolumeClaim{
+		ObjectMeta: metav1.ObjectMeta{Name: spec.Name},
+	}
+
+	if spec.Storage == "" {
+		errs = append(errs, field.Required(fldPath.Child("storage"), "storage request is required"))
+	} else if quantity, err := resource.ParseQuantity(spec.Storage); err != nil {
+		errs = append(errs, field.Invalid(fldPath.Child("storage"), spec.Storage, err.Error()))
+	} else if quantity.Sign() <= 0 {
+		errs = append(errs, field.Invalid(fldPath.Child("storage"), spec.Storage, "must be greater than 0"))
+	} else {
+		claim.Spec.Resources.Requests = corev1.ResourceList{corev1.ResourceStorage: quantity}
+	}
+
+	accessModes := spec.AccessModes
+	if len(accessModes) == 0 {
+		accessModes = []string{string(corev1.ReadWriteOnce)}
+	}
+	for i, m := range accessModes {
+		mode := corev1.PersistentVolumeAccessMode(m)
+		switch mode {
+		case corev1.ReadWriteOnce, corev1.ReadOnlyMany, corev1.ReadWriteMany, corev1.ReadWriteOncePod:
+			claim.Spec.AccessModes = append(claim.Spec.AccessModes, mode)
+		default:
+			errs = append(errs, field.NotSupported(fldPath.Child("accessModes").Index(i), m,
+				[]string{"ReadWriteOnce", "ReadOnlyMany", "ReadWriteMany", "ReadWriteOncePod"}))
+		}
+	}
+
+	if spec.StorageClass != "" {
+		for _, msg := range validation.IsDNS1123Subdomain(spec.StorageClass) {
+			errs = append(errs, field.Invalid(fldPath.Child("storageClass"), spec.StorageClass, msg))
+		}
+		storageClass := spec.StorageClass
+		claim.Spec.StorageClassName = &storageClass
+	}
+
+	return claim, errs
+}
+
+// statefulSetOrdinals reports the pod behind every expected ordinal, plus any
+// leftover pods above the desired count that are still being scaled down
+func statefulSetOrdinals(s *appsv1.StatefulSet, pods []corev1.Pod) []models.StatefulSetOrdinal {
+	replicas := 1
+	if s.Spec.Replicas != nil {
+		replicas = int(*s.Spec.Replicas)
+	}
+	start := 0
+	if s.Spec.Ordinals != nil {
+		start = int(s.Spec.Ordinals.Start)
+	}
+
+	byOrdinal := make(map[int]*corev1.Pod, len(pods))
+	for i := range pods {
+		suffix := strings.TrimPrefix(pods[i].Name, s.Name+"-")
+		if suffix == pods[i].Name {
+			continue
+		}
+		ordinal, err := strconv.Atoi(suffix)
+		if err != nil || ordinal < 0 {
+			continue
+		}
+		byOrdinal[ordinal] = &pods[i]
+	}
+
+	ordinals := make([]int, 0, replicas+len(byOrdinal))
+	for i := start; i < start+replicas; i++ {
+		ordinals = append(ordinals, i)
+	}
+	for ordinal := range byOrdinal {
+		if ordinal < start || ordinal >= start+replicas {
+			ordinals = append(ordinals, ordinal)
+		}
+	}
+	sort.Ints(ordinals)
+
+	result := make([]models.StatefulSetOrdinal, 0, len(ordinals))
+	for _, ordinal := range ordinals {
+		entry := models.StatefulSetOrdinal{
+			Ordinal: ordinal,
+			Pod:     s.Name + "-" + strconv.Itoa(ordinal),
+		}
+		if pod, ok := byOrdinal[ordinal]; ok {
+			entry.Exists = true
+			entry.Ready = podConditionTrue(pod, corev1.PodReady)
+			entry.Status, _ = podDisplayStatus(pod)
+			entry.Revision = pod.Labels[appsv1.ControllerRevisionHashLabelKey]
+			entry.Updated = entry.Revision != "" && entry.Revision == s.Status.UpdateRevision
+			entry.NodeName = pod.Spec.NodeName
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// toStatefulSet converts a statefulset into the simplified view
+func toStatefulSet(s *appsv1.StatefulSet) models.StatefulSet {
+	replicas := int32(1)
+	if s.Spec.Replicas != nil {
+		replicas = *s.Spec.Replicas
+	}
+
+	images := make([]string, 0, len(s.Spec.Template.Spec.Containers))
+	for _, c := range s.Spec.Template.Spec.Containers {
+		images = append(images, c.Image)
+	}
+
+	claims := make([]models.VolumeClaimTemplate, 0, len(s.Spec.VolumeClaimTemplates))
+	for _, c := range s.Spec.VolumeClaimTemplates {
+		claim := models.VolumeClaimTemplate{Name: c.Name}
+		if c.Spec.StorageClassName != nil {
+			claim.StorageClass = *c.Spec.StorageClassName
+		}
+		for _, m := range c.Spec.AccessModes {
+			claim.AccessModes = append(claim.AccessModes, string(m))
+		}
+		if storage, ok := c.Spec.Resources.Requests[corev1.ResourceStorage]; ok {
+			claim.Storage = storage.String()
+		}
+		claims = append(claims, claim)
+	}
+
+	statefulSet := models.StatefulSet{
+		Name:                 s.Name,
+		Namespace:            s.Namespace,
+		Replicas:             replicas,
+		ReadyReplicas:        s.Status.ReadyReplicas,
+		CurrentReplicas:      s.Status.CurrentReplicas,
+		UpdatedReplicas:      s.Status.UpdatedReplicas,
+		AvailableReplicas:    s.Status.AvailableReplicas,
+		ServiceName:          s.Spec.ServiceName,
+		PodManagementPolicy:  string(s.Spec.PodManagementPolicy),
+		UpdateStrategy:       string(s.Spec.UpdateStrategy.Type),
+		CurrentRevision:      s.Status.CurrentRevision,
+		UpdateRevision:       s.Status.UpdateRevision,
+		Images:               images,
+		VolumeClaimTemplates: claims,
+		CreatedAt:            s.CreationTimestamp.Time.Format(time.RFC3339),
+		RestartedAt:          s.Spec.Template.Annotations[restartedAtAnnotation],
+		Labels:               s.Labels,
+	}
+	if s.Spec.UpdateStrategy.RollingUpdate != nil {
+		statefulSet.Partition = s.Spec.UpdateStrategy.RollingUpdate.Partition
+	}
+	return statefulSet
+}
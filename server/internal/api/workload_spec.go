@@ -0,0 +1,576 @@
+package api
+
+import (
+	"encoding/json"
+	"fmt"
+	"k8_gui/internal/models"
+	"k8_gui/internal/utils"
+	"log"
+	"net/http"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+)
+
+// buildPodTemplate converts and validates the pod-level options shared by workload create requests.
+// claimNames lists volumes supplied outside the pod spec, such as statefulset volume claim templates.
+func buildPodTemplate(labels, annotations map[string]string, opts models.PodTemplateOptions, claimNames []string, fldPath *field.Path) (corev1.PodTemplateSpec, field.ErrorList) {
+	var errs field.ErrorList
+
+	// A nil fldPath means the options sit at the top level of the request
+	childPath := func(name string) *field.Path {
+		if fldPath == nil {
+			return field.NewPath(name)
+		}
+		return fldPath.Child(name)
+	}
+
+	template := corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{
+			Labels:      labels,
+			Annotations: annotations,
+		},
+		Spec: corev1.PodSpec{
+			NodeSelector:       opts.NodeSelector,
+			ServiceAccountName: opts.ServiceAccountName,
+		},
+	}
+
+	if len(opts.Containers) == 0 {
+		errs = append(errs, field.Required(childPath("containers"), "at least one container is required"))
+	}
+
+	containerNames := make(map[string]bool, len(opts.Containers))
+	for i, c := range opts.Containers {
+		idxPath := childPath("containers").Index(i)
+		if containerNames[c.Name] {
+			errs = append(errs, field.Duplicate(idxPath.Child("name"), c.Name))
+		}
+		containerNames[c.Name] = true
+
+		container, cErrs := buildContainer(c, idxPath)
+		errs = append(errs, cErrs...)
+		template.Spec.Containers = append(template.Spec.Containers, container)
+	}
+
+	volumeNames := make(map[string]bool, len(opts.Volumes))
+	for i, v := range opts.Volumes {
+		idxPath := childPath("volumes").Index(i)
+		if volumeNames[v.Name] {
+			errs = append(errs, field.Duplicate(idxPath.Child("name"), v.Name))
+		}
+		volumeNames[v.Name] = true
+
+		volume, vErrs := buildVolume(v, idxPath)
+		errs = append(errs, vErrs...)
+		template.Spec.Volumes = append(template.Spec.Volumes, volume)
+	}
+	for _, n := range claimNames {
+		volumeNames[n] = true
+	}
+
+	for i, c := range opts.Containers {
+		for j, m := range c.VolumeMounts {
+			if !volumeNames[m.Name] {
+				errs = append(errs, field.NotFound(childPath("containers").Index(i).Child("volumeMounts").Index(j).Child("name"), m.Name))
+			}
+		}
+	}
+
+	for i, s := range opts.ImagePullSecrets {
+		for _, msg := range validation.IsDNS1123Subdomain(s) {
+			errs = append(errs, field.Invalid(childPath("imagePullSecrets").Index(i), s, msg))
+		}
+		template.Spec.ImagePullSecrets = append(template.Spec.ImagePullSecrets, corev1.LocalObjectReference{Name: s})
+	}
+
+	errs = append(errs, validateLabels(opts.NodeSelector, childPath("nodeSelector"))...)
+
+	for i, t := range opts.Tolerations {
+		toleration, tErrs := buildToleration(t, childPath("tolerations").Index(i))
+		errs = append(errs, tErrs...)
+		template.Spec.Tolerations = append(template.Spec.Tolerations, toleration)
+	}
+
+	if len(opts.Affinity) > 0 {
+		affinity := &corev1.Affinity{}
+		if err := json.Unmarshal(opts.Affinity, affinity); err != nil {
+			errs = append(errs, field.Invalid(childPath("affinity"), string(opts.Affinity), err.Error()))
+		} else {
+			template.Spec.Affinity = affinity
+		}
+	}
+
+	return template, errs
+}
+
+// buildContainer converts and validates a single container spec
+func buildContainer(spec models.ContainerSpec, fldPath *field.Path) (corev1.Container, field.ErrorList) {
+	var errs field.ErrorList
+
+	for _, msg := range validation.IsDNS1123Label(spec.Name) {
+		errs = append(errs, field.Invalid(fldPath.Child("name"), spec.Name, msg))
+	}
+	if strings.TrimSpace(spec.Image) == "" {
+		errs = append(errs, field.Required(fldPath.Child("image"), "image is required"))
+	}
+
+	container := corev1.Container{
+		Name:    spec.Name,
+		Image:   spec.Image,
+		Command: spec.Command,
+		Args:    spec.Args,
+	}
+
+	switch corev1.PullPolicy(spec.ImagePullPolicy) {
+	case "", corev1.PullAlways, corev1.PullIfNotPresent, corev1.PullNever:
+		container.ImagePullPolicy = corev1.PullPolicy(spec.ImagePullPolicy)
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("imagePullPolicy"), spec.ImagePullPolicy,
+			[]string{string(corev1.PullAlways), string(corev1.PullIfNotPresent), string(corev1.PullNever)}))
+	}
+
+	for i, p := range spec.Ports {
+		port, pErrs := buildContainerPort(p, fldPath.Child("ports").Index(i))
+		errs = append(errs, pErrs...)
+		container.Ports = append(container.Ports, port)
+	}
+
+	env, envErrs := buildEnv(spec.Env, fldPath.Child("env"))
+	errs = append(errs, envErrs...)
+	container.Env = env
+
+	for i, e := range spec.EnvFrom {
+		idxPath := fldPath.Child("envFrom").Index(i)
+		source := corev1.EnvFromSource{Prefix: e.Prefix}
+		switch {
+		case e.ConfigMap != "" && e.Secret != "":
+			errs = append(errs, field.Forbidden(idxPath, "only one of configMap or secret may be set"))
+		case e.ConfigMap != "":
+			source.ConfigMapRef = &corev1.ConfigMapEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: e.ConfigMap}}
+		case e.Secret != "":
+			source.SecretRef = &corev1.SecretEnvSource{LocalObjectReference: corev1.LocalObjectReference{Name: e.Secret}}
+		default:
+			errs = append(errs, field.Required(idxPath, "one of configMap or secret is required"))
+		}
+		container.EnvFrom = append(container.EnvFrom, source)
+	}
+
+	resources, rErrs := buildResources(spec.Resources, fldPath.Child("resources"))
+	errs = append(errs, rErrs...)
+	container.Resources = resources
+
+	for i, m := range spec.VolumeMounts {
+		idxPath := fldPath.Child("volumeMounts").Index(i)
+		if !strings.HasPrefix(m.MountPath, "/") {
+			errs = append(errs, field.Invalid(idxPath.Child("mountPath"), m.MountPath, "must be an absolute path"))
+		}
+		container.VolumeMounts = append(container.VolumeMounts, corev1.VolumeMount{
+			Name:      m.Name,
+			MountPath: m.MountPath,
+			SubPath:   m.SubPath,
+			ReadOnly:  m.ReadOnly,
+		})
+	}
+
+	var pErrs field.ErrorList
+	container.LivenessProbe, pErrs = buildProbe(spec.LivenessProbe, fldPath.Child("livenessProbe"))
+	errs = append(errs, pErrs...)
+	container.ReadinessProbe, pErrs = buildProbe(spec.ReadinessProbe, fldPath.Child("readinessProbe"))
+	errs = append(errs, pErrs...)
+	container.StartupProbe, pErrs = buildProbe(spec.StartupProbe, fldPath.Child("startupProbe"))
+	errs = append(errs, pErrs...)
+
+	return container, errs
+}
+
+func buildContainerPort(p models.ContainerPort, fldPath *field.Path) (corev1.ContainerPort, field.ErrorList) {
+	var errs field.ErrorList
+
+	for _, msg := range validation.IsValidPortNum(int(p.ContainerPort)) {
+		errs = append(errs, field.Invalid(fldPath.Child("containerPort"), p.ContainerPort, msg))
+	}
+	if p.Name != "" {
+		for _, msg := range validation.IsValidPortName(p.Name) {
+			errs = append(errs, field.Invalid(fldPath.Child("name"), p.Name, msg))
+		}
+	}
+	protocol, pErrs := parseProtocol(p.Protocol, fldPath.Child("protocol"))
+	errs = append(errs, pErrs...)
+
+	return corev1.ContainerPort{
+		Name:          p.Name,
+		ContainerPort: p.ContainerPort,
+		Protocol:      protocol,
+	}, errs
+}
+
+// buildEnv converts env vars; each must set exactly one of value, configMapKeyRef, secretKeyRef or fieldRef
+func buildEnv(vars []models.EnvVar, fldPath *field.Path) ([]corev1.EnvVar, field.ErrorList) {
+	var errs field.ErrorList
+	env := make([]corev1.EnvVar, 0, len(vars))
+
+	for i, e := range vars {
+		idxPath := fldPath.Index(i)
+		for _, msg := range validation.IsEnvVarName(e.Name) {
+			errs = append(errs, field.Invalid(idxPath.Child("name"), e.Name, msg))
+		}
+
+		envVar := corev1.EnvVar{Name: e.Name, Value: e.Value}
+		sources := 0
+		if e.ConfigMapKeyRef != nil {
+			sources++
+			errs = append(errs, validateKeyRef(e.ConfigMapKeyRef, idxPath.Child("configMapKeyRef"))...)
+			envVar.ValueFrom = &corev1.EnvVarSource{ConfigMapKeyRef: &corev1.ConfigMapKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: e.ConfigMapKeyRef.Name},
+				Key:                  e.ConfigMapKeyRef.Key,
+				Optional:             optionalBool(e.ConfigMapKeyRef.Optional),
+			}}
+		}
+		if e.SecretKeyRef != nil {
+			sources++
+			errs = append(errs, validateKeyRef(e.SecretKeyRef, idxPath.Child("secretKeyRef"))...)
+			envVar.ValueFrom = &corev1.EnvVarSource{SecretKeyRef: &corev1.SecretKeySelector{
+				LocalObjectReference: corev1.LocalObjectReference{Name: e.SecretKeyRef.Name},
+				Key:                  e.SecretKeyRef.Key,
+				Optional:             optionalBool(e.SecretKeyRef.Optional),
+			}}
+		}
+		if e.FieldRef != "" {
+			sources++
+			envVar.ValueFrom = &corev1.EnvVarSource{FieldRef: &corev1.ObjectFieldSelector{FieldPath: e.FieldRef}}
+		}
+		if sources > 1 || (sources == 1 && e.Value != "") {
+			errs = append(errs, field.Forbidden(idxPath, "only one of value, configMapKeyRef, secretKeyRef or fieldRef may be set"))
+		}
+
+		env = append(env, envVar)
+	}
+	return env, errs
+}
+
+func validateKeyRef(ref *models.KeyRef, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for _, msg := range validation.IsDNS1123Subdomain(ref.Name) {
+		errs = append(errs, field.Invalid(fldPath.Child("name"), ref.Name, msg))
+	}
+	for _, msg := range validation.IsConfigMapKey(ref.Key) {
+		errs = append(errs, field.Invalid(fldPath.Child("key"), ref.Key, msg))
+	}
+	return errs
+}
+
+// buildResources parses request and limit quantities and checks requests do not exceed limits
+func buildResources(spec models.ResourceRequirements, fldPath *field.Path) (corev1.ResourceRequirements, field.ErrorList) {
+	var errs field.ErrorList
+	requests, rErrs := parseResourceList(spec.Requests, fldPath.Child("requests"))
+	errs = append(errs, rErrs...)
+	limits, lErrs := parseResourceList(spec.Limits, fldPath.Child("limits"))
+	errs = append(errs, lErrs...)
+
+	for name, request := range requests {
+		if limit, ok := limits[name]; ok && request.Cmp(limit) > 0 {
+			errs = append(errs, field.Invalid(fldPath.Child("requests").Key(string(name)), request.String(),
+				fmt.Sprintf("must be less than or equal to %s limit of %s", name, limit.String())))
+		}
+	}
+
+	return corev1.ResourceRequirements{Requests: requests, Limits: limits}, errs
+}
+
+func parseResourceList(values map[string]string, fldPath *field.Path) (corev1.ResourceList, field.ErrorList) {
+	if len(values) == 0 {
+		return nil, nil
+	}
+
+	var errs field.ErrorList
+	list := make(corev1.ResourceList, len(values))
+	for name, value := range values {
+		quantity, err := resource.ParseQuantity(value)
+		if err != nil {
+			errs = append(errs, field.Invalid(fldPath.Key(name), value, err.Error()))
+			continue
+		}
+		if quantity.Sign() < 0 {
+			errs = append(errs, field.Invalid(fldPath.Key(name), value, "must be greater than or equal to 0"))
+			continue
+		}
+		list[corev1.ResourceName(name)] = quantity
+	}
+	return list, errs
+}
+
+// buildProbe converts a probe; exactly one handler must be set
+func buildProbe(spec *models.ProbeSpec, fldPath *field.Path) (*corev1.Probe, field.ErrorList) {
+	if spec == nil {
+		return nil, nil
+	}
+
+	var errs field.ErrorList
+	probe := &corev1.Probe{
+		InitialDelaySeconds: spec.InitialDelaySeconds,
+		PeriodSeconds:       spec.PeriodSeconds,
+		TimeoutSeconds:      spec.TimeoutSeconds,
+		SuccessThreshold:    spec.SuccessThreshold,
+		FailureThreshold:    spec.FailureThreshold,
+	}
+
+	handlers := 0
+	if spec.HTTPGet != nil {
+		handlers++
+		scheme := corev1.URIScheme(strings.ToUpper(spec.HTTPGet.Scheme))
+		if scheme == "" {
+			scheme = corev1.URISchemeHTTP
+		}
+		if scheme != corev1.URISchemeHTTP && scheme != corev1.URISchemeHTTPS {
+			errs = append(errs, field.NotSupported(fldPath.Child("httpGet", "scheme"), spec.HTTPGet.Scheme, []string{"HTTP", "HTTPS"}))
+		}
+		port, pErrs := parsePortRef(spec.HTTPGet.Port, fldPath.Child("httpGet", "port"))
+		errs = append(errs, pErrs...)
+		path := spec.HTTPGet.Path
+		if path == "" {
+			path = "/"
+		}
+		probe.HTTPGet = &corev1.HTTPGetAction{Path: path, Port: port, Scheme: scheme}
+	}
+	if spec.TCPSocketPort != "" {
+		handlers++
+		port, pErrs := parsePortRef(spec.TCPSocketPort, fldPath.Child("tcpSocketPort"))
+		errs = append(errs, pErrs...)
+		probe.TCPSocket = &corev1.TCPSocketAction{Port: port}
+	}
+	if len(spec.Exec) > 0 {
+		handlers++
+		probe.Exec = &corev1.ExecAction{Command: spec.Exec}
+	}
+	if spec.GRPCPort != 0 {
+		handlers++
+		for _, msg := range validation.IsValidPortNum(int(spec.GRPCPort)) {
+			errs = append(errs, field.Invalid(fldPath.Child("grpcPort"), spec.GRPCPort, msg))
+		}
+		probe.GRPC = &corev1.GRPCAction{Port: spec.GRPCPort}
+	}
+
+	switch {
+	case handlers == 0:
+		errs = append(errs, field.Required(fldPath, "one of httpGet, tcpSocketPort, exec or grpcPort is required"))
+	case handlers > 1:
+		errs = append(errs, field.Forbidden(fldPath, "only one of httpGet, tcpSocketPort, exec or grpcPort may be set"))
+	}
+
+	for name, value := range map[string]int32{
+		"initialDelaySeconds": spec.InitialDelaySeconds,
+		"periodSeconds":       spec.PeriodSeconds,
+		"timeoutSeconds":      spec.TimeoutSeconds,
+		"successThreshold":    spec.SuccessThreshold,
+		"failureThreshold":    spec.FailureThreshold,
+	} {
+		if value < 0 {
+			errs = append(errs, field.Invalid(fldPath.Child(name), value, "must be greater than or equal to 0"))
+		}
+	}
+
+	return probe, errs
+}
+
+// parsePortRef parses a port given either as a number or as a named container port
+func parsePortRef(value string, fldPath *field.Path) (intstr.IntOrString, field.ErrorList) {
+	port := intstr.Parse(value)
+	if port.Type == intstr.Int {
+		for _, msg := range validation.IsValidPortNum(port.IntValue()) {
+			return port, field.ErrorList{field.Invalid(fldPath, value, msg)}
+		}
+		return port, nil
+	}
+	var errs field.ErrorList
+	for _, msg := range validation.IsValidPortName(value) {
+		errs = append(errs, field.Invalid(fldPath, value, msg))
+	}
+	return port, errs
+}
+
+func parseProtocol(value string, fldPath *field.Path) (corev1.Protocol, field.ErrorList) {
+	protocol := corev1.Protocol(strings.ToUpper(value))
+	switch protocol {
+	case "":
+		return corev1.ProtocolTCP, nil
+	case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+		return protocol, nil
+	}
+	return protocol, field.ErrorList{field.NotSupported(fldPath, value, []string{"TCP", "UDP", "SCTP"})}
+}
+
+// buildVolume converts a volume; exactly one source must be set
+func buildVolume(spec models.VolumeSpec, fldPath *field.Path) (corev1.Volume, field.ErrorList) {
+	var errs field.ErrorList
+	for _, msg := range validation.IsDNS1123Label(spec.Name) {
+		errs = append(errs, field.Invalid(fldPath.Child("name"), spec.Name, msg))
+	}
+
+	volume := corev1.Volume{Name: spec.Name}
+	sources := 0
+	if spec.ConfigMap != "" {
+		sources++
+		volume.ConfigMap = &corev1.ConfigMapVolumeSource{LocalObjectReference: corev1.LocalObjectReference{Name: spec.ConfigMap}}
+	}
+	if spec.Secret != "" {
+		sources++
+		volume.Secret = &corev1.SecretVolumeSource{SecretName: spec.Secret}
+	}
+	if spec.PersistentVolumeClaim != "" {
+		sources++
+		volume.PersistentVolumeClaim = &corev1.PersistentVolumeClaimVolumeSource{ClaimName: spec.PersistentVolumeClaim}
+	}
+	if spec.EmptyDir {
+		sources++
+		volume.EmptyDir = &corev1.EmptyDirVolumeSource{}
+	}
+
+	switch {
+	case sources == 0:
+		errs = append(errs, field.Required(fldPath, "one of configMap, secret, persistentVolumeClaim or emptyDir is required"))
+	case sources > 1:
+		errs = append(errs, field.Forbidden(fldPath, "only one of configMap, secret, persistentVolumeClaim or emptyDir may be set"))
+	}
+	return volume, errs
+}
+
+func buildToleration(t models.Toleration, fldPath *field.Path) (corev1.Toleration, field.ErrorList) {
+	var errs field.ErrorList
+
+	if t.Key != "" {
+		for _, msg := range validation.IsQualifiedName(t.Key) {
+			errs = append(errs, field.Invalid(fldPath.Child("key"), t.Key, msg))
+		}
+	}
+
+	operator := corev1.TolerationOperator(t.Operator)
+	switch operator {
+	case "", corev1.TolerationOpEqual:
+		if t.Key == "" {
+			errs = append(errs, field.Invalid(fldPath.Child("operator"), t.Operator, "operator must be Exists when key is empty"))
+		}
+	case corev1.TolerationOpExists:
+		if t.Value != "" {
+			errs = append(errs, field.Invalid(fldPath.Child("value"), t.Value, "value must be empty when operator is Exists"))
+		}
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("operator"), t.Operator, []string{"Equal", "Exists"}))
+	}
+
+	effect := corev1.TaintEffect(t.Effect)
+	switch effect {
+	case "", corev1.TaintEffectNoSchedule, corev1.TaintEffectPreferNoSchedule, corev1.TaintEffectNoExecute:
+	default:
+		errs = append(errs, field.NotSupported(fldPath.Child("effect"), t.Effect, []string{"NoSchedule", "PreferNoSchedule", "NoExecute"}))
+	}
+	if t.TolerationSeconds != nil && effect != corev1.TaintEffectNoExecute {
+		errs = append(errs, field.Invalid(fldPath.Child("tolerationSeconds"), *t.TolerationSeconds, "only allowed with the NoExecute effect"))
+	}
+
+	return corev1.Toleration{
+		Key:               t.Key,
+		Operator:          operator,
+		Value:             t.Value,
+		Effect:            effect,
+		TolerationSeconds: t.TolerationSeconds,
+	}, errs
+}
+
+// validateLabels checks label keys and values use valid Kubernetes syntax
+func validateLabels(labels map[string]string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	for k, v := range labels {
+		for _, msg := range validation.IsQualifiedName(k) {
+			errs = append(errs, field.Invalid(fldPath.Key(k), k, msg))
+		}
+		for _, msg := range validation.IsValidLabelValue(v) {
+			errs = append(errs, field.Invalid(fldPath.Key(k), v, msg))
+		}
+	}
+	return errs
+}
+
+// validateObjectName checks a resource name is a valid DNS subdomain
+func validateObjectName(name string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if name == "" {
+		return field.ErrorList{field.Required(fldPath, "name is required")}
+	}
+	for _, msg := range validation.IsDNS1123Subdomain(name) {
+		errs = append(errs, field.Invalid(fldPath, name, msg))
+	}
+	return errs
+}
+
+// validateNamespace checks a namespace is a valid DNS label, which is stricter than object names
+func validateNamespace(namespace string, fldPath *field.Path) field.ErrorList {
+	var errs field.ErrorList
+	if namespace == "" {
+		return field.ErrorList{field.Required(fldPath, "namespace is required")}
+	}
+	for _, msg := range validation.IsDNS1123Label(namespace) {
+		errs = append(errs, field.Invalid(fldPath, namespace, msg))
+	}
+	return errs
+}
+
+func optionalBool(b bool) *bool {
+	if !b {
+		return nil
+	}
+	return &b
+}
+
+// writeValidationErrors responds with 422 and one entry per invalid field
+func writeValidationErrors(w http.ResponseWriter, errs []models.FieldError) {
+	response := models.ValidationErrorResponse{
+		Message: utils.MsgValidationFailed,
+		Errors:  errs,
+	}
+
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(http.StatusUnprocessableEntity)
+	if err := json.NewEncoder(w).Encode(response); err != nil {
+		log.Printf(utils.LogFailedEncodeValidationErrors, err)
+	}
+}
+
+// fieldErrors converts a validation error list into response entries
+func fieldErrors(errs field.ErrorList) []models.FieldError {
+	result := make([]models.FieldError, 0, len(errs))
+	for _, e := range errs {
+		result = append(result, models.FieldError{
+			Field:   e.Field,
+			Message: e.ErrorBody(),
+		})
+	}
+	return result
+}
+
+// apiValidationErrors extracts per-field causes from an Invalid error returned by the API server
+func apiValidationErrors(err error) ([]models.FieldError, bool) {
+	if !apierrors.IsInvalid(err) {
+		return nil, false
+	}
+
+	var errs []models.FieldError
+	if status, ok := err.(apierrors.APIStatus); ok && status.Status().Details != nil {
+		for _, cause := range status.Status().Details.Causes {
+			errs = append(errs, models.FieldError{
+				Field:   cause.Field,
+				Message: cause.Message,
+			})
+		}
+	}
+	if len(errs) == 0 {
+		errs = append(errs, models.FieldError{Message: err.Error()})
+	}
+	return errs, true
+}
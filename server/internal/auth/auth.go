@@ -1,6 +1,7 @@
 package auth
 
 import (
+	"context"
 	"encoding/json"
 	"fmt"
 	"net/http"
@@ -127,7 +128,40 @@ func ValidateJWTMiddleware(next http.Handler) http.Handler {
 			return
 		}
 
-		// Token is valid, continue to the handler
-		next.ServeHTTP(w, r)
+		// Token is valid, continue to the handler with the caller's identity attached
+		ctx := context.WithValue(r.Context(), usernameKey{}, claims.Username)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+type usernameKey struct{}
+
+// UsernameFromContext returns the authenticated username stored by ValidateJWTMiddleware
+func UsernameFromContext(ctx context.Context) string {
+	username, _ := ctx.Value(usernameKey{}).(string)
+	return username
+}
+
+// getSecretRevealUsers returns the users allowed to read secret values from SECRET_REVEAL_USERS.
+// Revealing is opt-in: when the variable is unset nobody is allowed.
+func getSecretRevealUsers() []string {
+	users := os.Getenv("SECRET_REVEAL_USERS")
+	if users == "" {
+		return nil
+	}
+	return strings.Split(users, ",")
+}
+
+// RequireSecretReveal only lets users listed in SECRET_REVEAL_USERS through and denies everyone when it is unset
+func RequireSecretReveal(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		username := UsernameFromContext(r.Context())
+		for _, u := range getSecretRevealUsers() {
+			if username != "" && strings.TrimSpace(u) == username {
+				next.ServeHTTP(w, r)
+				return
+			}
+		}
+		http.Error(w, utils.MsgSecretRevealForbidden, http.StatusForbidden)
 	})
 }
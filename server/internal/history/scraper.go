@@ -0,0 +1,188 @@
+package history
+
+import (
+	"context"
+	"k8_gui/internal/utils"
+	"log"
+	"time"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/informers"
+	"k8s.io/client-go/kubernetes"
+	appslisters "k8s.io/client-go/listers/apps/v1"
+	corelisters "k8s.io/client-go/listers/core/v1"
+	"k8s.io/client-go/tools/cache"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// Scraper periodically copies node and pod usage from the metrics API into a Store
+type Scraper struct {
+	store         *Store
+	clientset     kubernetes.Interface
+	metricsClient metricsclientset.Interface
+
+	// Pod and ReplicaSet owners come from watch caches rather than a full list on every scrape
+	pods         corelisters.PodLister
+	replicaSets  appslisters.ReplicaSetLister
+	ownersSynced []cache.InformerSynced
+}
+
+// NewScraper creates a scraper writing into store
+func NewScraper(store *Store, clientset kubernetes.Interface, metricsClient metricsclientset.Interface) *Scraper {
+	return &Scraper{store: store, clientset: clientset, metricsClient: metricsClient}
+}
+
+// Start scrapes immediately and then every configured interval until ctx is done
+func (s *Scraper) Start(ctx context.Context) {
+	interval := s.store.Config().Interval
+
+	factory := informers.NewSharedInformerFactory(s.clientset, 0)
+	podInformer := factory.Core().V1().Pods()
+	rsInformer := factory.Apps().V1().ReplicaSets()
+	// Only names and owner references are needed, so keep the cached objects small
+	_ = podInformer.Informer().SetTransform(ownerMetadataOnly)
+	_ = rsInformer.Informer().SetTransform(ownerMetadataOnly)
+	s.pods = podInformer.Lister()
+	s.replicaSets = rsInformer.Lister()
+	s.ownersSynced = []cache.InformerSynced{podInformer.Informer().HasSynced, rsInformer.Informer().HasSynced}
+	factory.Start(ctx.Done())
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			s.scrape(ctx, interval)
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// scrape records one sample per node and pod plus namespace and deployment totals
+func (s *Scraper) scrape(ctx context.Context, timeout time.Duration) {
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	// All series of one scrape share a timestamp so aggregates line up with their parts
+	now := time.Now()
+
+	nodes, err := s.metricsClient.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Printf(utils.LogFailedScrapeNodeMetrics, err)
+	} else {
+		for _, m := range nodes.Items {
+			s.store.Append(NodeKey(m.Name), usageSample(now, m.Usage))
+		}
+	}
+
+	pods, err := s.metricsClient.MetricsV1beta1().PodMetricses("").List(ctx, metav1.ListOptions{})
+	if err != nil {
+		log.Printf(utils.LogFailedScrapePodMetrics, err)
+	} else {
+		deployments := s.podDeployments()
+		namespaces := make(map[string]Sample)
+		owners := make(map[string]Sample)
+
+		for _, m := range pods.Items {
+			sample := Sample{Time: now}
+			for _, c := range m.Containers {
+				cpu := c.Usage[corev1.ResourceCPU]
+				memory := c.Usage[corev1.ResourceMemory]
+				sample.CPU += cpu.MilliValue()
+				sample.Memory += memory.Value()
+			}
+			s.store.Append(PodKey(m.Namespace, m.Name), sample)
+
+			namespaces[m.Namespace] = addSample(namespaces[m.Namespace], sample)
+			if deployment, ok := deployments[m.Namespace+"/"+m.Name]; ok {
+				key := DeploymentKey(m.Namespace, deployment)
+				owners[key] = addSample(owners[key], sample)
+			}
+		}
+
+		for namespace, sample := range namespaces {
+			s.store.Append(NamespaceKey(namespace), sample)
+		}
+		for key, sample := range owners {
+			s.store.Append(key, sample)
+		}
+	}
+
+	s.store.Compact(now)
+}
+
+// podDeployments maps namespace/pod to the name of the deployment owning the pod's ReplicaSet.
+// It returns nil until the owner caches have synced.
+func (s *Scraper) podDeployments() map[string]string {
+	for _, synced := range s.ownersSynced {
+		if !synced() {
+			return nil
+		}
+	}
+
+	replicaSets, err := s.replicaSets.List(labels.Everything())
+	if err != nil {
+		log.Printf(utils.LogFailedScrapeOwners, err)
+		return nil
+	}
+	rsDeployments := make(map[string]string, len(replicaSets))
+	for _, rs := range replicaSets {
+		if owner := metav1.GetControllerOf(rs); owner != nil && owner.Kind == "Deployment" {
+			rsDeployments[rs.Namespace+"/"+rs.Name] = owner.Name
+		}
+	}
+
+	pods, err := s.pods.List(labels.Everything())
+	if err != nil {
+		log.Printf(utils.LogFailedScrapeOwners, err)
+		return nil
+	}
+	result := make(map[string]string)
+	for _, pod := range pods {
+		if owner := metav1.GetControllerOf(pod); owner != nil && owner.Kind == "ReplicaSet" {
+			if deployment, ok := rsDeployments[pod.Namespace+"/"+owner.Name]; ok {
+				result[pod.Namespace+"/"+pod.Name] = deployment
+			}
+		}
+	}
+	return result
+}
+
+// ownerMetadataOnly strips cached pods and ReplicaSets down to the metadata used to resolve owners
+func ownerMetadataOnly(obj interface{}) (interface{}, error) {
+	switch o := obj.(type) {
+	case *corev1.Pod:
+		return &corev1.Pod{ObjectMeta: ownerMeta(o.ObjectMeta)}, nil
+	case *appsv1.ReplicaSet:
+		return &appsv1.ReplicaSet{ObjectMeta: ownerMeta(o.ObjectMeta)}, nil
+	}
+	return obj, nil
+}
+
+func ownerMeta(meta metav1.ObjectMeta) metav1.ObjectMeta {
+	return metav1.ObjectMeta{
+		Name:            meta.Name,
+		Namespace:       meta.Namespace,
+		UID:             meta.UID,
+		ResourceVersion: meta.ResourceVersion,
+		OwnerReferences: meta.OwnerReferences,
+	}
+}
+
+// usageSample converts a metrics API usage list into a sample
+func usageSample(now time.Time, usage corev1.ResourceList) Sample {
+	cpu := usage[corev1.ResourceCPU]
+	memory := usage[corev1.ResourceMemory]
+	return Sample{Time: now, CPU: cpu.MilliValue(), Memory: memory.Value()}
+}
+
+// addSample sums two samples taken at the same time
+func addSample(total, sample Sample) Sample {
+	return Sample{Time: sample.Time, CPU: total.CPU + sample.CPU, Memory: total.Memory + sample.Memory}
+}
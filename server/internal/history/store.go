@@ -0,0 +1,207 @@
+package history
+
+import (
+	"os"
+	"sort"
+	"sync"
+	"time"
+)
+
+// Sample is a single CPU and memory observation of a node, pod or aggregate
+type Sample struct {
+	Time time.Time
+	// CPU is in millicores
+	CPU int64
+	// Memory is in bytes
+	Memory int64
+}
+
+// Config controls how often metrics are scraped and how long they are kept
+type Config struct {
+	// Interval between scrapes of the metrics API
+	Interval time.Duration
+	// RawRetention is how long samples are kept at full resolution
+	RawRetention time.Duration
+	// Retention is how long samples are kept at all
+	Retention time.Duration
+	// Resolution is the bucket size samples are averaged into once they are older than RawRetention
+	Resolution time.Duration
+}
+
+// DefaultConfig keeps an hour of raw samples and a day of five minute averages
+var DefaultConfig = Config{
+	Interval:     30 * time.Second,
+	RawRetention: time.Hour,
+	Retention:    24 * time.Hour,
+	Resolution:   5 * time.Minute,
+}
+
+// ConfigFromEnv reads the METRICS_HISTORY_* environment variables, falling back to DefaultConfig
+func ConfigFromEnv() Config {
+	config := Config{
+		Interval:     envDuration("METRICS_HISTORY_INTERVAL", DefaultConfig.Interval),
+		RawRetention: envDuration("METRICS_HISTORY_RAW_RETENTION", DefaultConfig.RawRetention),
+		Retention:    envDuration("METRICS_HISTORY_RETENTION", DefaultConfig.Retention),
+		Resolution:   envDuration("METRICS_HISTORY_RESOLUTION", DefaultConfig.Resolution),
+	}
+	if config.RawRetention > config.Retention {
+		config.RawRetention = config.Retention
+	}
+	return config
+}
+
+// envDuration reads a positive duration such as 30s or 1h from the environment, falling back to def
+func envDuration(key string, def time.Duration) time.Duration {
+	if v := os.Getenv(key); v != "" {
+		if parsed, err := time.ParseDuration(v); err == nil && parsed > 0 {
+			return parsed
+		}
+	}
+	return def
+}
+
+// NodeKey returns the series key of a node
+func NodeKey(name string) string {
+	return "node/" + name
+}
+
+// PodKey returns the series key of a pod
+func PodKey(namespace, name string) string {
+	return "pod/" + namespace + "/" + name
+}
+
+// NamespaceKey returns the series key of the summed usage of a namespace
+func NamespaceKey(namespace string) string {
+	return "namespace/" + namespace
+}
+
+// DeploymentKey returns the series key of the summed usage of a deployment's pods
+func DeploymentKey(namespace, name string) string {
+	return "deployment/" + namespace + "/" + name
+}
+
+// series holds the samples of one key: recent ones at full resolution, older ones downsampled
+type series struct {
+	raw         []Sample
+	downsampled []Sample
+}
+
+// Store is an in-memory time-series store with retention and downsampling
+type Store struct {
+	mu     sync.RWMutex
+	config Config
+	series map[string]*series
+}
+
+// NewStore creates an empty store
+func NewStore(config Config) *Store {
+	return &Store{config: config, series: make(map[string]*series)}
+}
+
+// Config returns the configuration the store was created with
+func (s *Store) Config() Config {
+	return s.config
+}
+
+// Append records a sample; samples must be appended in time order per key
+func (s *Store) Append(key string, sample Sample) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ser, ok := s.series[key]
+	if !ok {
+		ser = &series{}
+		s.series[key] = ser
+	}
+	ser.raw = append(ser.raw, sample)
+}
+
+// Compact downsamples samples older than the raw retention and drops samples older than the retention.
+// Only whole resolution buckets are downsampled, so a bucket is never averaged twice.
+func (s *Store) Compact(now time.Time) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	cutoff := now.Add(-s.config.RawRetention).Truncate(s.config.Resolution)
+	expiry := now.Add(-s.config.Retention)
+
+	for key, ser := range s.series {
+		moved := sort.Search(len(ser.raw), func(i int) bool { return !ser.raw[i].Time.Before(cutoff) })
+		if moved > 0 {
+			ser.downsampled = append(ser.downsampled, downsample(ser.raw[:moved], s.config.Resolution)...)
+			ser.raw = append([]Sample(nil), ser.raw[moved:]...)
+		}
+
+		expired := sort.Search(len(ser.downsampled), func(i int) bool { return !ser.downsampled[i].Time.Before(expiry) })
+		if expired > 0 {
+			ser.downsampled = append([]Sample(nil), ser.downsampled[expired:]...)
+		}
+
+		if len(ser.raw) == 0 && len(ser.downsampled) == 0 {
+			delete(s.series, key)
+		}
+	}
+}
+
+// Query returns the samples of a key between from and to inclusive. With a positive step the
+// samples are averaged into buckets of that size starting at from.
+func (s *Store) Query(key string, from, to time.Time, step time.Duration) []Sample {
+	s.mu.RLock()
+	var samples []Sample
+	if ser, ok := s.series[key]; ok {
+		// Downsampled samples are all older than raw ones, so the result stays in time order
+		samples = append(samples, window(ser.downsampled, from, to)...)
+		samples = append(samples, window(ser.raw, from, to)...)
+	}
+	s.mu.RUnlock()
+
+	if step <= 0 || len(samples) == 0 {
+		return samples
+	}
+	return average(samples, from, step)
+}
+
+// window returns the samples between from and to inclusive
+func window(samples []Sample, from, to time.Time) []Sample {
+	start := sort.Search(len(samples), func(i int) bool { return !samples[i].Time.Before(from) })
+	end := sort.Search(len(samples), func(i int) bool { return samples[i].Time.After(to) })
+	if start >= end {
+		return nil
+	}
+	return samples[start:end]
+}
+
+// downsample averages samples into buckets aligned to resolution
+func downsample(samples []Sample, resolution time.Duration) []Sample {
+	if len(samples) == 0 {
+		return nil
+	}
+	return average(samples, samples[0].Time.Truncate(resolution), resolution)
+}
+
+// average averages time-ordered samples into buckets of size step starting at origin,
+// stamping each bucket with its start time
+func average(samples []Sample, origin time.Time, step time.Duration) []Sample {
+	var result []Sample
+	var cpu, memory, count int64
+	var bucket time.Time
+
+	flush := func() {
+		if count > 0 {
+			result = append(result, Sample{Time: bucket, CPU: cpu / count, Memory: memory / count})
+		}
+	}
+	for _, sample := range samples {
+		start := origin.Add(sample.Time.Sub(origin) / step * step)
+		if count > 0 && !start.Equal(bucket) {
+			flush()
+			cpu, memory, count = 0, 0, 0
+		}
+		bucket = start
+		cpu += sample.CPU
+		memory += sample.Memory
+		count++
+	}
+	flush()
+	return result
+}
@@ -10,29 +10,30 @@ import (
 	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 )
 
-// InitK8sClient initializes Kubernetes and Metrics clients
-func InitK8sClient() (*kubernetes.Clientset, *metricsclientset.Clientset, error) {
+// InitK8sClient initializes Kubernetes and Metrics clients along with the
+// REST config they were built from (needed for exec-based operations)
+func InitK8sClient() (*kubernetes.Clientset, *metricsclientset.Clientset, *rest.Config, error) {
 	// Try in-cluster config
 	config, err := rest.InClusterConfig()
 	if err != nil {
 		kubeconfig := filepath.Join(homedir.HomeDir(), ".kube", "config")
 		config, err = clientcmd.BuildConfigFromFlags("", kubeconfig)
 		if err != nil {
-			return nil, nil, err
+			return nil, nil, nil, err
 		}
 	}
 
 	clientset, err := kubernetes.NewForConfig(config)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
 	metricsClient, err := metricsclientset.NewForConfig(config)
 	if err != nil {
-		return nil, nil, err
+		return nil, nil, nil, err
 	}
 
-	return clientset, metricsClient, nil
+	return clientset, metricsClient, config, nil
 }
 
 
@@ -0,0 +1,128 @@
+package metricsource
+
+import (
+	"context"
+	"fmt"
+	"k8_gui/internal/history"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+)
+
+// MetricsServer reads current CPU and memory from the metrics.k8s.io API and answers range
+// queries from the history store the scraper fills
+type MetricsServer struct {
+	client metricsclientset.Interface
+	store  *history.Store
+}
+
+// NewMetricsServer creates a provider backed by metrics-server; store may be nil to disable range queries
+func NewMetricsServer(client metricsclientset.Interface, store *history.Store) *MetricsServer {
+	return &MetricsServer{client: client, store: store}
+}
+
+// Name implements Provider
+func (m *MetricsServer) Name() string {
+	return "metrics-server"
+}
+
+// NodeUsage implements Provider
+func (m *MetricsServer) NodeUsage(ctx context.Context, name string) (*NodeUsage, error) {
+	metrics, err := m.client.MetricsV1beta1().NodeMetricses().Get(ctx, name, metav1.GetOptions{})
+	if apierrors.IsNotFound(err) {
+		return nil, ErrNoData
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &NodeUsage{
+		Name:      metrics.Name,
+		Usage:     resourceUsage(metrics.Usage),
+		Timestamp: metrics.Timestamp.Time,
+		Window:    metrics.Window.Duration,
+	}, nil
+}
+
+// NodesUsage implements Provider
+func (m *MetricsServer) NodesUsage(ctx context.Context) ([]NodeUsage, error) {
+	metrics, err := m.client.MetricsV1beta1().NodeMetricses().List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]NodeUsage, 0, len(metrics.Items))
+	for _, n := range metrics.Items {
+		result = append(result, NodeUsage{
+			Name:      n.Name,
+			Usage:     resourceUsage(n.Usage),
+			Timestamp: n.Timestamp.Time,
+			Window:    n.Window.Duration,
+		})
+	}
+	return result, nil
+}
+
+// PodsUsage implements Provider
+func (m *MetricsServer) PodsUsage(ctx context.Context, namespace string) ([]PodUsage, error) {
+	metrics, err := m.client.MetricsV1beta1().PodMetricses(namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]PodUsage, 0, len(metrics.Items))
+	for _, p := range metrics.Items {
+		pod := PodUsage{
+			Namespace:  p.Namespace,
+			Name:       p.Name,
+			Containers: make([]ContainerUsage, 0, len(p.Containers)),
+			Timestamp:  p.Timestamp.Time,
+			Window:     p.Window.Duration,
+		}
+		for _, c := range p.Containers {
+			usage := resourceUsage(c.Usage)
+			pod.Usage.CPU += usage.CPU
+			pod.Usage.Memory += usage.Memory
+			pod.Containers = append(pod.Containers, ContainerUsage{Name: c.Name, Usage: usage})
+		}
+		result = append(result, pod)
+	}
+	return result, nil
+}
+
+// Range implements Provider from the history store
+func (m *MetricsServer) Range(ctx context.Context, target Target, from, to time.Time, step time.Duration) ([]Point, error) {
+	if m.store == nil {
+		return nil, ErrRangeUnsupported
+	}
+
+	var key string
+	switch target.Kind {
+	case KindNode:
+		key = history.NodeKey(target.Name)
+	case KindPod:
+		key = history.PodKey(target.Namespace, target.Name)
+	case KindNamespace:
+		key = history.NamespaceKey(target.Namespace)
+	case KindDeployment:
+		key = history.DeploymentKey(target.Namespace, target.Name)
+	default:
+		return nil, fmt.Errorf("unknown target kind %q", target.Kind)
+	}
+
+	samples := m.store.Query(key, from, to, step)
+	points := make([]Point, 0, len(samples))
+	for _, s := range samples {
+		points = append(points, Point{Time: s.Time, Usage: Usage{CPU: s.CPU, Memory: s.Memory}})
+	}
+	return points, nil
+}
+
+// resourceUsage converts a metrics API usage list
+func resourceUsage(list corev1.ResourceList) Usage {
+	cpu := list[corev1.ResourceCPU]
+	memory := list[corev1.ResourceMemory]
+	return Usage{CPU: cpu.MilliValue(), Memory: memory.Value()}
+}
@@ -0,0 +1,577 @@
+package metricsource
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/url"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+	"time"
+)
+
+// QueryTemplates are the PromQL queries the Prometheus provider runs, as text/template strings.
+// Each template receives .Selector (label matchers to add to the series selector), .GroupBy
+// (the labels to sum by) and .Window (the rate window). Node queries must keep the node label
+// and pod queries the namespace, pod and container labels they are grouped by.
+//
+// DeploymentPods selects the pods owned by a deployment for deployment history; it receives
+// .Selector (the namespace matcher) and .Owner (an owner_name matcher for the deployment) and must
+// return one series per namespace and pod. Pod queries are joined with it on namespace and pod.
+type QueryTemplates struct {
+	NodeCPU             string `json:"nodeCpu"`
+	NodeMemory          string `json:"nodeMemory"`
+	NodeNetworkReceive  string `json:"nodeNetworkReceive"`
+	NodeNetworkTransmit string `json:"nodeNetworkTransmit"`
+	NodeFilesystem      string `json:"nodeFilesystem"`
+	PodCPU              string `json:"podCpu"`
+	PodMemory           string `json:"podMemory"`
+	PodNetworkReceive   string `json:"podNetworkReceive"`
+	PodNetworkTransmit  string `json:"podNetworkTransmit"`
+	PodFilesystem       string `json:"podFilesystem"`
+	DeploymentPods      string `json:"deploymentPods"`
+}
+
+// DefaultQueryTemplates use the cAdvisor metrics scraped from the kubelet, as in kube-prometheus.
+// Node figures come from the root cgroup (id="/") so no node-exporter relabelling is needed.
+var DefaultQueryTemplates = QueryTemplates{
+	NodeCPU:             `sum by ({{.GroupBy}}) (rate(container_cpu_usage_seconds_total{id="/",{{.Selector}}}[{{.Window}}]))`,
+	NodeMemory:          `sum by ({{.GroupBy}}) (container_memory_working_set_bytes{id="/",{{.Selector}}})`,
+	NodeNetworkReceive:  `sum by ({{.GroupBy}}) (rate(container_network_receive_bytes_total{id="/",{{.Selector}}}[{{.Window}}]))`,
+	NodeNetworkTransmit: `sum by ({{.GroupBy}}) (rate(container_network_transmit_bytes_total{id="/",{{.Selector}}}[{{.Window}}]))`,
+	NodeFilesystem:      `sum by ({{.GroupBy}}) (container_fs_usage_bytes{id="/",{{.Selector}}})`,
+	PodCPU:              `sum by ({{.GroupBy}}) (rate(container_cpu_usage_seconds_total{container!="",container!="POD",{{.Selector}}}[{{.Window}}]))`,
+	PodMemory:           `sum by ({{.GroupBy}}) (container_memory_working_set_bytes{container!="",container!="POD",{{.Selector}}})`,
+	PodNetworkReceive:   `sum by ({{.GroupBy}}) (rate(container_network_receive_bytes_total{pod!="",{{.Selector}}}[{{.Window}}]))`,
+	PodNetworkTransmit:  `sum by ({{.GroupBy}}) (rate(container_network_transmit_bytes_total{pod!="",{{.Selector}}}[{{.Window}}]))`,
+	PodFilesystem:       `sum by ({{.GroupBy}}) (container_fs_usage_bytes{container!="",container!="POD",{{.Selector}}})`,
+	// Resolves pods through their ReplicaSet's owner using kube-state-metrics, like the ownerReferences
+	// walk the metrics-server history does
+	DeploymentPods: `max by (namespace, pod) (label_replace(kube_pod_owner{owner_kind="ReplicaSet",{{.Selector}}}, "replicaset", "$1", "owner_name", "(.*)")` +
+		` * on (namespace, replicaset) group_left() max by (namespace, replicaset) (kube_replicaset_owner{owner_kind="Deployment",{{.Selector}},{{.Owner}}}))`,
+}
+
+// PrometheusConfig configures the Prometheus provider
+type PrometheusConfig struct {
+	// URL is the Prometheus base URL, e.g. http://prometheus.monitoring:9090
+	URL string
+	// Window is the rate window used by the queries; defaults to 5m
+	Window string
+	// Timeout bounds each request to Prometheus; defaults to 10s
+	Timeout time.Duration
+	// Queries defaults to DefaultQueryTemplates
+	Queries QueryTemplates
+	// HTTPClient is used instead of a client built from Timeout when set
+	HTTPClient *http.Client
+}
+
+// PrometheusConfigFromEnv reads PROMETHEUS_URL, PROMETHEUS_RATE_WINDOW, PROMETHEUS_TIMEOUT and
+// PROMETHEUS_QUERIES_FILE, a JSON file overriding individual query templates
+func PrometheusConfigFromEnv() (PrometheusConfig, error) {
+	config := PrometheusConfig{
+		URL:     os.Getenv("PROMETHEUS_URL"),
+		Window:  os.Getenv("PROMETHEUS_RATE_WINDOW"),
+		Queries: DefaultQueryTemplates,
+	}
+	if v := os.Getenv("PROMETHEUS_TIMEOUT"); v != "" {
+		timeout, err := time.ParseDuration(v)
+		if err != nil {
+			return config, fmt.Errorf("PROMETHEUS_TIMEOUT: %w", err)
+		}
+		config.Timeout = timeout
+	}
+	if path := os.Getenv("PROMETHEUS_QUERIES_FILE"); path != "" {
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return config, fmt.Errorf("PROMETHEUS_QUERIES_FILE: %w", err)
+		}
+		// Keys missing from the file keep their defaults
+		if err := json.Unmarshal(data, &config.Queries); err != nil {
+			return config, fmt.Errorf("PROMETHEUS_QUERIES_FILE: %w", err)
+		}
+	}
+	return config, nil
+}
+
+// usageField is the part of Usage a query fills
+type usageField int
+
+const (
+	fieldCPU usageField = iota
+	fieldMemory
+	fieldNetworkReceive
+	fieldNetworkTransmit
+	fieldFilesystem
+)
+
+// levelQueries are the parsed templates for nodes or pods, indexed by usageField
+type levelQueries [5]*template.Template
+
+// templateData is passed to the query templates
+type templateData struct {
+	Selector string
+	GroupBy  string
+	Window   string
+	Owner    string
+}
+
+// Group-by labels for the aggregation levels the provider queries
+const (
+	groupNode      = "node"
+	groupContainer = "namespace, pod, container"
+	groupPod       = "namespace, pod"
+	groupNamespace = "namespace"
+)
+
+// defaultRangePoints is how many points a range query returns when no step is given
+const defaultRangePoints = 250
+
+// Prometheus reads current and historical usage from a Prometheus server
+type Prometheus struct {
+	baseURL        *url.URL
+	client         *http.Client
+	window         string
+	period         time.Duration
+	node           levelQueries
+	pod            levelQueries
+	deploymentPods *template.Template
+}
+
+// NewPrometheus creates a provider, validating the URL, window and query templates up front
+func NewPrometheus(config PrometheusConfig) (*Prometheus, error) {
+	if config.URL == "" {
+		return nil, fmt.Errorf("prometheus URL is required")
+	}
+	baseURL, err := url.Parse(config.URL)
+	if err != nil || baseURL.Scheme == "" || baseURL.Host == "" {
+		return nil, fmt.Errorf("invalid prometheus URL %q", config.URL)
+	}
+
+	window := config.Window
+	if window == "" {
+		window = "5m"
+	}
+	// Go durations are a subset of PromQL ones, which keeps Window usable on both sides
+	period, err := time.ParseDuration(window)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rate window %q: %w", window, err)
+	}
+
+	client := config.HTTPClient
+	if client == nil {
+		timeout := config.Timeout
+		if timeout <= 0 {
+			timeout = 10 * time.Second
+		}
+		client = &http.Client{Timeout: timeout}
+	}
+
+	p := &Prometheus{baseURL: baseURL, client: client, window: window, period: period}
+	q := config.Queries
+	for i, source := range []string{q.NodeCPU, q.NodeMemory, q.NodeNetworkReceive, q.NodeNetworkTransmit, q.NodeFilesystem} {
+		if p.node[i], err = parseQueryTemplate(source); err != nil {
+			return nil, err
+		}
+	}
+	for i, source := range []string{q.PodCPU, q.PodMemory, q.PodNetworkReceive, q.PodNetworkTransmit, q.PodFilesystem} {
+		if p.pod[i], err = parseQueryTemplate(source); err != nil {
+			return nil, err
+		}
+	}
+	if p.deploymentPods, err = parseQueryTemplate(q.DeploymentPods); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// parseQueryTemplate parses a query template; an empty template disables that query
+func parseQueryTemplate(source string) (*template.Template, error) {
+	if source == "" {
+		return nil, nil
+	}
+	t, err := template.New("query").Option("missingkey=error").Parse(source)
+	if err != nil {
+		return nil, fmt.Errorf("invalid query template %q: %w", source, err)
+	}
+	return t, nil
+}
+
+// Name implements Provider
+func (p *Prometheus) Name() string {
+	return "prometheus"
+}
+
+// NodeUsage implements Provider
+func (p *Prometheus) NodeUsage(ctx context.Context, name string) (*NodeUsage, error) {
+	nodes, err := p.nodes(ctx, matcher("node", name))
+	if err != nil {
+		return nil, err
+	}
+	if len(nodes) == 0 {
+		return nil, ErrNoData
+	}
+	return &nodes[0], nil
+}
+
+// NodesUsage implements Provider
+func (p *Prometheus) NodesUsage(ctx context.Context) ([]NodeUsage, error) {
+	return p.nodes(ctx, `node!=""`)
+}
+
+// nodes runs the node queries for the selector and merges the results by node
+func (p *Prometheus) nodes(ctx context.Context, selector string) ([]NodeUsage, error) {
+	now := time.Now()
+	byName := make(map[string]*NodeUsage)
+
+	for field, t := range p.node {
+		series, err := p.query(ctx, t, templateData{Selector: selector, GroupBy: groupNode, Window: p.window}, now)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range series {
+			name := s.Metric["node"]
+			if name == "" {
+				continue
+			}
+			node, ok := byName[name]
+			if !ok {
+				node = &NodeUsage{Name: name, Timestamp: now, Window: p.period}
+				byName[name] = node
+			}
+			if _, value, err := s.Value.parse(); err == nil {
+				setUsage(&node.Usage, usageField(field), value)
+			}
+		}
+	}
+
+	result := make([]NodeUsage, 0, len(byName))
+	for _, node := range byName {
+		result = append(result, *node)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].Name < result[j].Name })
+	return result, nil
+}
+
+// PodsUsage implements Provider
+func (p *Prometheus) PodsUsage(ctx context.Context, namespace string) ([]PodUsage, error) {
+	selector := `namespace!=""`
+	if namespace != "" {
+		selector = matcher("namespace", namespace)
+	}
+
+	now := time.Now()
+	byKey := make(map[string]*PodUsage)
+	containers := make(map[string]map[string]*Usage)
+
+	for field, t := range p.pod {
+		// Network counters only exist per pod sandbox, the rest per container
+		groupBy := groupContainer
+		if usageField(field) == fieldNetworkReceive || usageField(field) == fieldNetworkTransmit {
+			groupBy = groupPod
+		}
+		series, err := p.query(ctx, t, templateData{Selector: selector, GroupBy: groupBy, Window: p.window}, now)
+		if err != nil {
+			return nil, err
+		}
+
+		for _, s := range series {
+			ns, name := s.Metric["namespace"], s.Metric["pod"]
+			if ns == "" || name == "" {
+				continue
+			}
+			_, value, err := s.Value.parse()
+			if err != nil {
+				continue
+			}
+			key := ns + "/" + name
+			pod, ok := byKey[key]
+			if !ok {
+				pod = &PodUsage{Namespace: ns, Name: name, Timestamp: now, Window: p.period}
+				byKey[key] = pod
+				containers[key] = make(map[string]*Usage)
+			}
+
+			addUsage(&pod.Usage, usageField(field), value)
+			if container := s.Metric["container"]; groupBy == groupContainer && container != "" {
+				usage, ok := containers[key][container]
+				if !ok {
+					usage = &Usage{}
+					containers[key][container] = usage
+				}
+				setUsage(usage, usageField(field), value)
+			}
+		}
+	}
+
+	result := make([]PodUsage, 0, len(byKey))
+	for key, pod := range byKey {
+		for name, usage := range containers[key] {
+			pod.Containers = append(pod.Containers, ContainerUsage{Name: name, Usage: *usage})
+		}
+		sort.Slice(pod.Containers, func(i, j int) bool { return pod.Containers[i].Name < pod.Containers[j].Name })
+		result = append(result, *pod)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Namespace != result[j].Namespace {
+			return result[i].Namespace < result[j].Namespace
+		}
+		return result[i].Name < result[j].Name
+	})
+	return result, nil
+}
+
+// Range implements Provider with query_range requests
+func (p *Prometheus) Range(ctx context.Context, target Target, from, to time.Time, step time.Duration) ([]Point, error) {
+	queries := p.pod
+	data := templateData{Window: p.window}
+	switch target.Kind {
+	case KindNode:
+		queries = p.node
+		data.Selector = matcher("node", target.Name)
+		data.GroupBy = groupNode
+	case KindPod:
+		data.Selector = matcher("namespace", target.Namespace) + "," + matcher("pod", target.Name)
+		data.GroupBy = groupPod
+	case KindNamespace:
+		data.Selector = matcher("namespace", target.Namespace)
+		data.GroupBy = groupNamespace
+	case KindDeployment:
+		if p.deploymentPods == nil {
+			return nil, ErrRangeUnsupported
+		}
+		// Per-pod series are summed after the join with the deployment's pods
+		data.Selector = matcher("namespace", target.Namespace)
+		data.GroupBy = groupPod
+		data.Owner = matcher("owner_name", target.Name)
+	default:
+		return nil, fmt.Errorf("unknown target kind %q", target.Kind)
+	}
+
+	rendered := make([]string, len(queries))
+	for field, t := range queries {
+		if t == nil {
+			continue
+		}
+		query, err := renderQuery(t, data)
+		if err != nil {
+			return nil, err
+		}
+		rendered[field] = query
+	}
+	if target.Kind == KindDeployment {
+		owned, err := renderQuery(p.deploymentPods, data)
+		if err != nil {
+			return nil, err
+		}
+		for field, query := range rendered {
+			if query != "" {
+				rendered[field] = "sum by (" + groupNamespace + ") ((" + query + ") * on (namespace, pod) (" + owned + "))"
+			}
+		}
+	}
+
+	if step <= 0 {
+		step = to.Sub(from) / defaultRangePoints
+		if step < time.Second {
+			step = time.Second
+		}
+		step = step.Round(time.Second)
+	}
+
+	byTime := make(map[int64]*Point)
+	for field, query := range rendered {
+		series, err := p.queryRange(ctx, query, from, to, step)
+		if err != nil {
+			return nil, err
+		}
+		for _, s := range series {
+			for _, v := range s.Values {
+				at, value, err := v.parse()
+				if err != nil {
+					continue
+				}
+				point, ok := byTime[at.UnixNano()]
+				if !ok {
+					point = &Point{Time: at}
+					byTime[at.UnixNano()] = point
+				}
+				addUsage(&point.Usage, usageField(field), value)
+			}
+		}
+	}
+
+	points := make([]Point, 0, len(byTime))
+	for _, point := range byTime {
+		points = append(points, *point)
+	}
+	sort.Slice(points, func(i, j int) bool { return points[i].Time.Before(points[j].Time) })
+	return points, nil
+}
+
+// query runs an instant query; a nil template returns no series
+func (p *Prometheus) query(ctx context.Context, t *template.Template, data templateData, at time.Time) ([]promSeries, error) {
+	if t == nil {
+		return nil, nil
+	}
+	query, err := renderQuery(t, data)
+	if err != nil {
+		return nil, err
+	}
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("time", formatPromTime(at))
+	return p.get(ctx, "/api/v1/query", params)
+}
+
+// queryRange runs a rendered range query; an empty query returns no series
+func (p *Prometheus) queryRange(ctx context.Context, query string, from, to time.Time, step time.Duration) ([]promSeries, error) {
+	if query == "" {
+		return nil, nil
+	}
+	params := url.Values{}
+	params.Set("query", query)
+	params.Set("start", formatPromTime(from))
+	params.Set("end", formatPromTime(to))
+	params.Set("step", strconv.FormatFloat(step.Seconds(), 'f', -1, 64))
+	return p.get(ctx, "/api/v1/query_range", params)
+}
+
+// promResponse is the envelope of the Prometheus HTTP API
+type promResponse struct {
+	Status    string `json:"status"`
+	ErrorType string `json:"errorType"`
+	Error     string `json:"error"`
+	Data      struct {
+		ResultType string       `json:"resultType"`
+		Result     []promSeries `json:"result"`
+	} `json:"data"`
+}
+
+// promSeries is one series of a vector (Value) or matrix (Values) result
+type promSeries struct {
+	Metric map[string]string `json:"metric"`
+	Value  promValue         `json:"value"`
+	Values []promValue       `json:"values"`
+}
+
+// promValue is a [unix seconds, "value"] pair
+type promValue []interface{}
+
+// parse returns the time and value of a sample
+func (v promValue) parse() (time.Time, float64, error) {
+	if len(v) != 2 {
+		return time.Time{}, 0, fmt.Errorf("malformed sample %v", []interface{}(v))
+	}
+	seconds, ok := v[0].(float64)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("malformed sample time %v", v[0])
+	}
+	text, ok := v[1].(string)
+	if !ok {
+		return time.Time{}, 0, fmt.Errorf("malformed sample value %v", v[1])
+	}
+	value, err := strconv.ParseFloat(text, 64)
+	if err != nil || math.IsNaN(value) || math.IsInf(value, 0) {
+		return time.Time{}, 0, fmt.Errorf("unusable sample value %q", text)
+	}
+	whole, frac := math.Modf(seconds)
+	return time.Unix(int64(whole), int64(frac*1e9)), value, nil
+}
+
+// get calls a Prometheus API endpoint and returns the series of a successful result
+func (p *Prometheus) get(ctx context.Context, path string, params url.Values) ([]promSeries, error) {
+	endpoint := *p.baseURL
+	endpoint.Path = strings.TrimSuffix(endpoint.Path, "/") + path
+	endpoint.RawQuery = params.Encode()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	var body promResponse
+	if err := json.NewDecoder(resp.Body).Decode(&body); err != nil {
+		return nil, fmt.Errorf("prometheus returned %s: %w", resp.Status, err)
+	}
+	if body.Status != "success" {
+		return nil, fmt.Errorf("prometheus query failed (%s): %s", body.ErrorType, body.Error)
+	}
+	return body.Data.Result, nil
+}
+
+// renderQuery executes a query template
+func renderQuery(t *template.Template, data templateData) (string, error) {
+	var buf bytes.Buffer
+	if err := t.Execute(&buf, data); err != nil {
+		return "", fmt.Errorf("rendering query template: %w", err)
+	}
+	return buf.String(), nil
+}
+
+// matcher returns an equality label matcher with the value quoted for PromQL
+func matcher(label, value string) string {
+	return label + "=" + strconv.Quote(value)
+}
+
+// formatPromTime formats a time as unix seconds with millisecond precision
+func formatPromTime(t time.Time) string {
+	return strconv.FormatFloat(float64(t.UnixMilli())/1000, 'f', 3, 64)
+}
+
+// setUsage stores a query result in the matching usage field; CPU arrives in cores
+func setUsage(usage *Usage, field usageField, value float64) {
+	switch field {
+	case fieldCPU:
+		usage.CPU = int64(math.Round(value * 1000))
+	case fieldMemory:
+		usage.Memory = int64(value)
+	case fieldNetworkReceive:
+		usage.NetworkReceive = &value
+	case fieldNetworkTransmit:
+		usage.NetworkTransmit = &value
+	case fieldFilesystem:
+		used := int64(value)
+		usage.Filesystem = &used
+	}
+}
+
+// addUsage adds a query result to the matching usage field
+func addUsage(usage *Usage, field usageField, value float64) {
+	var part Usage
+	setUsage(&part, field, value)
+
+	usage.CPU += part.CPU
+	usage.Memory += part.Memory
+	usage.NetworkReceive = addFloat(usage.NetworkReceive, part.NetworkReceive)
+	usage.NetworkTransmit = addFloat(usage.NetworkTransmit, part.NetworkTransmit)
+	if part.Filesystem != nil {
+		total := *part.Filesystem
+		if usage.Filesystem != nil {
+			total += *usage.Filesystem
+		}
+		usage.Filesystem = &total
+	}
+}
+
+// addFloat adds two optional values, staying nil when both are
+func addFloat(a, b *float64) *float64 {
+	if b == nil {
+		return a
+	}
+	total := *b
+	if a != nil {
+		total += *a
+	}
+	return &total
+}
@@ -0,0 +1,224 @@
+package metricsource
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// standIn serves canned Prometheus API responses picked by the metric name in the query
+type standIn struct {
+	responses map[string]string
+
+	mu      sync.Mutex
+	queries []string
+}
+
+func newStandIn(t *testing.T, responses map[string]string) (*standIn, *Prometheus) {
+	t.Helper()
+	s := &standIn{responses: responses}
+	server := httptest.NewServer(s)
+	t.Cleanup(server.Close)
+
+	provider, err := NewPrometheus(PrometheusConfig{URL: server.URL, Queries: DefaultQueryTemplates})
+	if err != nil {
+		t.Fatalf("NewPrometheus: %v", err)
+	}
+	return s, provider
+}
+
+func (s *standIn) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query().Get("query")
+	s.mu.Lock()
+	s.queries = append(s.queries, r.URL.Path+" "+query)
+	s.mu.Unlock()
+
+	for metric, body := range s.responses {
+		if strings.Contains(query, metric+"{") {
+			fmt.Fprint(w, body)
+			return
+		}
+	}
+	fmt.Fprint(w, `{"status":"success","data":{"resultType":"vector","result":[]}}`)
+}
+
+// vector builds a successful instant query response from series of labels and a value
+func vector(series ...string) string {
+	return `{"status":"success","data":{"resultType":"vector","result":[` + strings.Join(series, ",") + `]}}`
+}
+
+func sample(labels, value string) string {
+	return `{"metric":{` + labels + `},"value":[1700000000.5,"` + value + `"]}`
+}
+
+func TestPrometheusNodesUsage(t *testing.T) {
+	_, provider := newStandIn(t, map[string]string{
+		"container_cpu_usage_seconds_total":      vector(sample(`"node":"b"`, "0.25"), sample(`"node":"a"`, "1.5")),
+		"container_memory_working_set_bytes":     vector(sample(`"node":"a"`, "2048"), sample(`"node":"b"`, "1024")),
+		"container_network_receive_bytes_total":  vector(sample(`"node":"a"`, "NaN"), sample(`"node":"b"`, "10.5")),
+		"container_network_transmit_bytes_total": vector(sample(`"node":"a"`, "3")),
+		"container_fs_usage_bytes":               vector(sample(`"node":"a"`, "4096")),
+	})
+
+	nodes, err := provider.NodesUsage(context.Background())
+	if err != nil {
+		t.Fatalf("NodesUsage: %v", err)
+	}
+	if len(nodes) != 2 || nodes[0].Name != "a" || nodes[1].Name != "b" {
+		t.Fatalf("nodes = %+v, want a and b in order", nodes)
+	}
+
+	a, b := nodes[0].Usage, nodes[1].Usage
+	if a.CPU != 1500 || b.CPU != 250 {
+		t.Errorf("CPU = %d, %d millicores, want 1500, 250", a.CPU, b.CPU)
+	}
+	if a.Memory != 2048 || b.Memory != 1024 {
+		t.Errorf("Memory = %d, %d, want 2048, 1024", a.Memory, b.Memory)
+	}
+	if a.NetworkReceive != nil {
+		t.Errorf("NaN receive sample was kept: %v", *a.NetworkReceive)
+	}
+	if b.NetworkReceive == nil || *b.NetworkReceive != 10.5 {
+		t.Errorf("b NetworkReceive = %v, want 10.5", b.NetworkReceive)
+	}
+	if a.NetworkTransmit == nil || *a.NetworkTransmit != 3 || b.NetworkTransmit != nil {
+		t.Errorf("NetworkTransmit = %v, %v, want 3 and nil", a.NetworkTransmit, b.NetworkTransmit)
+	}
+	if a.Filesystem == nil || *a.Filesystem != 4096 || b.Filesystem != nil {
+		t.Errorf("Filesystem = %v, %v, want 4096 and nil", a.Filesystem, b.Filesystem)
+	}
+	if nodes[0].Window != 5*time.Minute {
+		t.Errorf("Window = %s, want 5m", nodes[0].Window)
+	}
+}
+
+func TestPrometheusPodsUsage(t *testing.T) {
+	web := `"namespace":"default","pod":"web"`
+	s, provider := newStandIn(t, map[string]string{
+		"container_cpu_usage_seconds_total": vector(
+			sample(web+`,"container":"app"`, "0.2"),
+			sample(web+`,"container":"sidecar"`, "0.05"),
+			sample(`"namespace":"default","pod":"db","container":"postgres"`, "NaN"),
+		),
+		"container_memory_working_set_bytes": vector(
+			sample(web+`,"container":"app"`, "100"),
+			sample(web+`,"container":"sidecar"`, "50"),
+		),
+		"container_network_receive_bytes_total": vector(sample(web, "7")),
+	})
+
+	pods, err := provider.PodsUsage(context.Background(), "default")
+	if err != nil {
+		t.Fatalf("PodsUsage: %v", err)
+	}
+	if len(pods) != 1 || pods[0].Name != "web" {
+		t.Fatalf("pods = %+v, want only web (db only had a NaN sample)", pods)
+	}
+
+	pod := pods[0]
+	if pod.Usage.CPU != 250 || pod.Usage.Memory != 150 {
+		t.Errorf("pod usage = %dm, %d bytes, want 250m, 150 bytes", pod.Usage.CPU, pod.Usage.Memory)
+	}
+	if pod.Usage.NetworkReceive == nil || *pod.Usage.NetworkReceive != 7 {
+		t.Errorf("pod NetworkReceive = %v, want 7", pod.Usage.NetworkReceive)
+	}
+	if len(pod.Containers) != 2 || pod.Containers[0].Name != "app" || pod.Containers[1].Name != "sidecar" {
+		t.Fatalf("containers = %+v, want app and sidecar", pod.Containers)
+	}
+	if c := pod.Containers[0].Usage; c.CPU != 200 || c.Memory != 100 || c.NetworkReceive != nil {
+		t.Errorf("app usage = %+v, want 200m, 100 bytes and no network", c)
+	}
+
+	for _, q := range s.queries {
+		if !strings.Contains(q, `namespace="default"`) {
+			t.Errorf("query is not limited to the namespace: %s", q)
+		}
+	}
+}
+
+func TestPrometheusRange(t *testing.T) {
+	matrix := func(values ...string) string {
+		return `{"status":"success","data":{"resultType":"matrix","result":[{"metric":{"namespace":"default"},"values":[` +
+			strings.Join(values, ",") + `]}]}}`
+	}
+	s, provider := newStandIn(t, map[string]string{
+		"container_cpu_usage_seconds_total":  matrix(`[1700000000,"0.5"]`, `[1700000060,"NaN"]`, `[1700000120,"1"]`),
+		"container_memory_working_set_bytes": matrix(`[1700000000,"10"]`, `[1700000060,"20"]`),
+	})
+
+	from := time.Unix(1700000000, 0)
+	points, err := provider.Range(context.Background(), Target{Kind: KindDeployment, Namespace: "default", Name: "web"}, from, from.Add(2*time.Minute), 0)
+	if err != nil {
+		t.Fatalf("Range: %v", err)
+	}
+	if len(points) != 3 {
+		t.Fatalf("points = %+v, want 3", points)
+	}
+	want := []Usage{{CPU: 500, Memory: 10}, {CPU: 0, Memory: 20}, {CPU: 1000, Memory: 0}}
+	for i, point := range points {
+		if !point.Time.Equal(from.Add(time.Duration(i) * time.Minute)) {
+			t.Errorf("point %d at %s, want %s", i, point.Time, from.Add(time.Duration(i)*time.Minute))
+		}
+		if point.Usage.CPU != want[i].CPU || point.Usage.Memory != want[i].Memory {
+			t.Errorf("point %d = %dm, %d bytes, want %dm, %d bytes", i, point.Usage.CPU, point.Usage.Memory, want[i].CPU, want[i].Memory)
+		}
+	}
+
+	for _, q := range s.queries {
+		if !strings.HasPrefix(q, "/api/v1/query_range ") {
+			t.Errorf("range used %s", q)
+		}
+		if !strings.Contains(q, `kube_replicaset_owner{owner_kind="Deployment",namespace="default",owner_name="web"}`) {
+			t.Errorf("deployment query is not joined with its owners: %s", q)
+		}
+	}
+}
+
+func TestPrometheusErrorResponse(t *testing.T) {
+	_, provider := newStandIn(t, map[string]string{
+		"container_cpu_usage_seconds_total": `{"status":"error","errorType":"bad_data","error":"parse error at char 5"}`,
+	})
+
+	if _, err := provider.NodesUsage(context.Background()); err == nil || !strings.Contains(err.Error(), "parse error at char 5") {
+		t.Errorf("NodesUsage error = %v, want the Prometheus error", err)
+	}
+	if _, err := provider.Range(context.Background(), Target{Kind: KindPod, Namespace: "default", Name: "web"}, time.Unix(0, 0), time.Unix(60, 0), time.Second); err == nil {
+		t.Error("Range succeeded on an error response")
+	}
+}
+
+func TestPrometheusDeploymentRangeUnsupported(t *testing.T) {
+	queries := DefaultQueryTemplates
+	queries.DeploymentPods = ""
+	provider, err := NewPrometheus(PrometheusConfig{URL: "http://prometheus.invalid", Queries: queries})
+	if err != nil {
+		t.Fatalf("NewPrometheus: %v", err)
+	}
+	_, err = provider.Range(context.Background(), Target{Kind: KindDeployment, Namespace: "default", Name: "web"}, time.Unix(0, 0), time.Unix(60, 0), 0)
+	if !errors.Is(err, ErrRangeUnsupported) {
+		t.Errorf("err = %v, want ErrRangeUnsupported", err)
+	}
+}
+
+func TestMatcherEscapesQuotes(t *testing.T) {
+	tests := []struct {
+		value string
+		want  string
+	}{
+		{`web`, `pod="web"`},
+		{`we"b`, `pod="we\"b"`},
+		{`we\b`, `pod="we\\b"`},
+		{"we\nb", `pod="we\nb"`},
+	}
+	for _, tt := range tests {
+		if got := matcher("pod", tt.value); got != tt.want {
+			t.Errorf("matcher(%q) = %s, want %s", tt.value, got, tt.want)
+		}
+	}
+}
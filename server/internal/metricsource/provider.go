@@ -0,0 +1,86 @@
+package metricsource
+
+import (
+	"context"
+	"errors"
+	"time"
+)
+
+// ErrNoData is returned when a provider has no samples for the requested object
+var ErrNoData = errors.New("no metrics available")
+
+// ErrRangeUnsupported is returned by providers that cannot answer range queries
+var ErrRangeUnsupported = errors.New("range queries are not supported by this metrics provider")
+
+// Provider supplies current and historical resource usage to the metrics handlers
+type Provider interface {
+	// Name identifies the provider in responses, e.g. metrics-server or prometheus
+	Name() string
+	// NodeUsage returns the current usage of one node, or ErrNoData
+	NodeUsage(ctx context.Context, name string) (*NodeUsage, error)
+	// NodesUsage returns the current usage of every node the provider has data for
+	NodesUsage(ctx context.Context) ([]NodeUsage, error)
+	// PodsUsage returns the current usage of pods in a namespace, or of all pods when namespace is empty
+	PodsUsage(ctx context.Context, namespace string) ([]PodUsage, error)
+	// Range returns usage of a target between from and to. A zero step lets the provider pick one.
+	Range(ctx context.Context, target Target, from, to time.Time, step time.Duration) ([]Point, error)
+}
+
+// Usage is the resource usage of a node, pod or container. Network and filesystem figures are
+// nil when the provider does not collect them.
+type Usage struct {
+	// CPU is in millicores
+	CPU int64
+	// Memory is the working set in bytes
+	Memory int64
+	// NetworkReceive and NetworkTransmit are in bytes per second
+	NetworkReceive  *float64
+	NetworkTransmit *float64
+	// Filesystem is the used filesystem space in bytes
+	Filesystem *int64
+}
+
+// NodeUsage is the usage of a node at a point in time
+type NodeUsage struct {
+	Name      string
+	Usage     Usage
+	Timestamp time.Time
+	Window    time.Duration
+}
+
+// PodUsage is the usage of a pod and its containers at a point in time
+type PodUsage struct {
+	Namespace  string
+	Name       string
+	Usage      Usage
+	Containers []ContainerUsage
+	Timestamp  time.Time
+	Window     time.Duration
+}
+
+// ContainerUsage is the usage of a single container
+type ContainerUsage struct {
+	Name  string
+	Usage Usage
+}
+
+// Target kinds accepted by Provider.Range
+const (
+	KindNode       = "Node"
+	KindPod        = "Pod"
+	KindNamespace  = "Namespace"
+	KindDeployment = "Deployment"
+)
+
+// Target identifies the object a range query is about
+type Target struct {
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// Point is the usage of a target at one time in a range
+type Point struct {
+	Time  time.Time
+	Usage Usage
+}
@@ -0,0 +1,62 @@
+package models
+
+// ConfigMap represents a simplified configmap view
+type ConfigMap struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	Keys            []string          `json:"keys"`
+	Immutable       bool              `json:"immutable"`
+	ResourceVersion string            `json:"resourceVersion"`
+	CreatedAt       string            `json:"createdAt"`
+	Labels          map[string]string `json:"labels,omitempty"`
+	// Data and BinaryData are only populated when fetching a single configmap
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+	// Consumers is only populated when requested with ?consumers=true
+	Consumers []ConfigMapConsumer `json:"consumers,omitempty"`
+}
+
+// ConfigMapConsumer represents a pod or workload that references a configmap
+type ConfigMapConsumer struct {
+	Kind      string `json:"kind"`
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Paused is set for paused deployments, which are not restarted
+	Paused bool `json:"paused,omitempty"`
+	// References describes how the configmap is used, e.g. "env:app/LOG_LEVEL", "envFrom:app" or "volume:config"
+	References []string `json:"references"`
+}
+
+// ConfigMapListResponse represents configmap list response
+type ConfigMapListResponse struct {
+	Items []ConfigMap `json:"items"`
+}
+
+// CreateConfigMapRequest represents the request body for creating a configmap
+type CreateConfigMapRequest struct {
+	Name       string            `json:"name"`
+	Namespace  string            `json:"namespace"`
+	Labels     map[string]string `json:"labels,omitempty"`
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+	Immutable  bool              `json:"immutable,omitempty"`
+}
+
+// UpdateConfigMapRequest replaces the contents of a configmap
+type UpdateConfigMapRequest struct {
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+	// Labels are left untouched when omitted
+	Labels map[string]string `json:"labels,omitempty"`
+	// ResourceVersion, when set, makes the update fail with 409 if the configmap changed since it was read
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+	// RestartConsumers triggers a rolling restart of every deployment, statefulset and daemonset using the configmap
+	RestartConsumers bool `json:"restartConsumers,omitempty"`
+}
+
+// UpdateConfigMapResponse represents the updated configmap and any consumers restarted afterwards
+type UpdateConfigMapResponse struct {
+	ConfigMap
+	Restarted     []ConfigMapConsumer `json:"restarted,omitempty"`
+	RestartErrors []string            `json:"restartErrors,omitempty"`
+}
@@ -0,0 +1,90 @@
+package models
+
+import "encoding/json"
+
+// ContainerSpec represents a container definition in create and update requests
+type ContainerSpec struct {
+	Name            string               `json:"name"`
+	Image           string               `json:"image"`
+	ImagePullPolicy string               `json:"imagePullPolicy,omitempty"`
+	Command         []string             `json:"command,omitempty"`
+	Args            []string             `json:"args,omitempty"`
+	Ports           []ContainerPort      `json:"ports,omitempty"`
+	Env             []EnvVar             `json:"env,omitempty"`
+	EnvFrom         []EnvFromSource      `json:"envFrom,omitempty"`
+	Resources       ResourceRequirements `json:"resources,omitempty"`
+	VolumeMounts    []VolumeMount        `json:"volumeMounts,omitempty"`
+	LivenessProbe   *ProbeSpec           `json:"livenessProbe,omitempty"`
+	ReadinessProbe  *ProbeSpec           `json:"readinessProbe,omitempty"`
+	StartupProbe    *ProbeSpec           `json:"startupProbe,omitempty"`
+}
+
+// EnvVar represents an environment variable set from a literal value or a reference
+type EnvVar struct {
+	Name            string  `json:"name"`
+	Value           string  `json:"value,omitempty"`
+	ConfigMapKeyRef *KeyRef `json:"configMapKeyRef,omitempty"`
+	SecretKeyRef    *KeyRef `json:"secretKeyRef,omitempty"`
+	FieldRef        string  `json:"fieldRef,omitempty"`
+}
+
+// KeyRef represents a reference to a key in a ConfigMap or Secret
+type KeyRef struct {
+	Name     string `json:"name"`
+	Key      string `json:"key"`
+	Optional bool   `json:"optional,omitempty"`
+}
+
+// EnvFromSource represents a ConfigMap or Secret whose keys are all exposed as env vars
+type EnvFromSource struct {
+	ConfigMap string `json:"configMap,omitempty"`
+	Secret    string `json:"secret,omitempty"`
+	Prefix    string `json:"prefix,omitempty"`
+}
+
+// ResourceRequirements represents container resource requests and limits
+type ResourceRequirements struct {
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+}
+
+// ProbeSpec represents a probe definition; exactly one handler must be set
+type ProbeSpec struct {
+	HTTPGet             *HTTPGetAction `json:"httpGet,omitempty"`
+	TCPSocketPort       string         `json:"tcpSocketPort,omitempty"`
+	Exec                []string       `json:"exec,omitempty"`
+	GRPCPort            int32          `json:"grpcPort,omitempty"`
+	InitialDelaySeconds int32          `json:"initialDelaySeconds,omitempty"`
+	PeriodSeconds       int32          `json:"periodSeconds,omitempty"`
+	TimeoutSeconds      int32          `json:"timeoutSeconds,omitempty"`
+	SuccessThreshold    int32          `json:"successThreshold,omitempty"`
+	FailureThreshold    int32          `json:"failureThreshold,omitempty"`
+}
+
+// HTTPGetAction represents an HTTP probe handler; Port is a number or a named port
+type HTTPGetAction struct {
+	Path   string `json:"path"`
+	Port   string `json:"port"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// VolumeSpec represents a pod volume; exactly one source must be set
+type VolumeSpec struct {
+	Name                  string `json:"name"`
+	ConfigMap             string `json:"configMap,omitempty"`
+	Secret                string `json:"secret,omitempty"`
+	PersistentVolumeClaim string `json:"persistentVolumeClaim,omitempty"`
+	EmptyDir              bool   `json:"emptyDir,omitempty"`
+}
+
+// PodTemplateOptions represents scheduling and pod-level settings shared by workload create requests
+type PodTemplateOptions struct {
+	Containers       []ContainerSpec   `json:"containers,omitempty"`
+	Volumes          []VolumeSpec      `json:"volumes,omitempty"`
+	ImagePullSecrets []string          `json:"imagePullSecrets,omitempty"`
+	NodeSelector     map[string]string `json:"nodeSelector,omitempty"`
+	Tolerations      []Toleration      `json:"tolerations,omitempty"`
+	// Affinity is a Kubernetes affinity object passed through as-is
+	Affinity           json.RawMessage `json:"affinity,omitempty"`
+	ServiceAccountName string          `json:"serviceAccountName,omitempty"`
+}
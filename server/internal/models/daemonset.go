@@ -0,0 +1,49 @@
+package models
+
+// DaemonSet represents a simplified daemonset view
+type DaemonSet struct {
+	Name                   string            `json:"name"`
+	Namespace              string            `json:"namespace"`
+	DesiredNumberScheduled int32             `json:"desiredNumberScheduled"`
+	CurrentNumberScheduled int32             `json:"currentNumberScheduled"`
+	NumberReady            int32             `json:"numberReady"`
+	UpdatedNumberScheduled int32             `json:"updatedNumberScheduled"`
+	NumberAvailable        int32             `json:"numberAvailable"`
+	NumberMisscheduled     int32             `json:"numberMisscheduled"`
+	NodeSelector           map[string]string `json:"nodeSelector,omitempty"`
+	UpdateStrategy         string            `json:"updateStrategy"`
+	Images                 []string          `json:"images"`
+	CreatedAt              string            `json:"createdAt"`
+	RestartedAt            string            `json:"restartedAt,omitempty"`
+	Labels                 map[string]string `json:"labels,omitempty"`
+}
+
+// DaemonSetListResponse represents daemonset list response
+type DaemonSetListResponse struct {
+	Items []DaemonSet `json:"items"`
+}
+
+// DaemonSetNode reports whether a node should run a daemon pod and whether it does
+type DaemonSetNode struct {
+	NodeName string `json:"nodeName"`
+	// Eligible is true when the node matches the node selector, required node affinity and tolerations
+	Eligible bool `json:"eligible"`
+	// Reason explains why an ineligible node is excluded
+	Reason string `json:"reason,omitempty"`
+	Pod    string `json:"pod,omitempty"`
+	Ready  bool   `json:"ready"`
+	Status string `json:"status,omitempty"`
+	// Missing is true when an eligible node has no daemon pod
+	Missing bool `json:"missing"`
+	// Misscheduled is true when a daemon pod runs on a node it should not keep running on: the node
+	// no longer matches the selector or affinity, or has an untolerated NoExecute taint. An untolerated
+	// NoSchedule taint only makes the node ineligible for new pods.
+	Misscheduled bool `json:"misscheduled"`
+}
+
+// DaemonSetNodesResponse represents the per-node view of a daemonset
+type DaemonSetNodesResponse struct {
+	Items        []DaemonSetNode `json:"items"`
+	Missing      []string        `json:"missing"`
+	Misscheduled []string        `json:"misscheduled"`
+}
@@ -1,5 +1,7 @@
 package models
 
+import "encoding/json"
+
 // Deployment represents a simplified deployment view
 type Deployment struct {
 	Name              string            `json:"name"`
@@ -8,6 +10,8 @@ type Deployment struct {
 	AvailableReplicas int32             `json:"availableReplicas"`
 	CreatedAt         string            `json:"createdAt"`
 	Strategy          string            `json:"strategy"`
+	Paused            bool              `json:"paused"`
+	RestartedAt       string            `json:"restartedAt,omitempty"`
 	Labels            map[string]string `json:"labels,omitempty"`
 }
 
@@ -16,17 +20,110 @@ type DeploymentListResponse struct {
 	Items []Deployment `json:"items"`
 }
 
-// CreateDeploymentRequest represents the request body for creating a deployment
+// CreateDeploymentRequest represents the request body for creating a deployment.
+// Image and Port are a shorthand for a single container named after the deployment
+// and are ignored when Containers is set.
 type CreateDeploymentRequest struct {
-	Name      string `json:"name"`
-	Namespace string `json:"namespace"`
-	Image     string `json:"image"`
-	Replicas  int32  `json:"replicas"`
-	Port      int32  `json:"port"`
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Image       string            `json:"image,omitempty"`
+	Replicas    int32             `json:"replicas"`
+	Port        int32             `json:"port,omitempty"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Selector defaults to Labels, or app=<name> when no labels are given
+	Selector map[string]string `json:"selector,omitempty"`
+	PodTemplateOptions
+	Strategy                *DeploymentStrategy `json:"strategy,omitempty"`
+	MinReadySeconds         int32               `json:"minReadySeconds,omitempty"`
+	RevisionHistoryLimit    *int32              `json:"revisionHistoryLimit,omitempty"`
+	ProgressDeadlineSeconds *int32              `json:"progressDeadlineSeconds,omitempty"`
+}
+
+// DeploymentStrategy represents rollout strategy parameters.
+// MaxSurge and MaxUnavailable accept an absolute number or a percentage such as "25%".
+type DeploymentStrategy struct {
+	Type           string `json:"type"`
+	MaxSurge       string `json:"maxSurge,omitempty"`
+	MaxUnavailable string `json:"maxUnavailable,omitempty"`
 }
 
-// UpdateDeploymentRequest represents the request body for updating a deployment
+// UpdateDeploymentRequest represents the request body for updating a deployment.
+// Image applies to the first container; omit Replicas to leave it unchanged.
 type UpdateDeploymentRequest struct {
 	Image    string `json:"image"`
-	Replicas int32  `json:"replicas"`
+	Replicas *int32 `json:"replicas,omitempty"`
+}
+
+// PatchDeploymentRequest represents a partial deployment update targeting containers by name.
+// When ResourceVersion is set the patch is rejected with 409 if the deployment changed since.
+type PatchDeploymentRequest struct {
+	ResourceVersion string           `json:"resourceVersion,omitempty"`
+	Replicas        *int32           `json:"replicas,omitempty"`
+	Containers      []ContainerPatch `json:"containers,omitempty"`
+}
+
+// ContainerPatch represents changes to a single named container
+type ContainerPatch struct {
+	Name      string                `json:"name"`
+	Image     string                `json:"image,omitempty"`
+	Env       []EnvVar              `json:"env,omitempty"`
+	RemoveEnv []string              `json:"removeEnv,omitempty"`
+	Resources *ResourceRequirements `json:"resources,omitempty"`
+}
+
+// DeploymentRevision represents a single rollout revision backed by a ReplicaSet
+type DeploymentRevision struct {
+	Revision    int64           `json:"revision"`
+	ReplicaSet  string          `json:"replicaSet"`
+	ChangeCause string          `json:"changeCause,omitempty"`
+	Images      []string        `json:"images"`
+	Replicas    int32           `json:"replicas"`
+	CreatedAt   string          `json:"createdAt"`
+	Current     bool            `json:"current"`
+	Diff        json.RawMessage `json:"diff,omitempty"`
+}
+
+// DeploymentHistoryResponse represents the rollout history of a deployment
+type DeploymentHistoryResponse struct {
+	Name            string               `json:"name"`
+	Namespace       string               `json:"namespace"`
+	CurrentRevision int64                `json:"currentRevision"`
+	Items           []DeploymentRevision `json:"items"`
+}
+
+// RollbackDeploymentRequest represents the request body for rolling back a deployment.
+// A zero revision rolls back to the previous one.
+type RollbackDeploymentRequest struct {
+	Revision int64 `json:"revision"`
+}
+
+// RolloutStatus represents the progress of a deployment rollout
+type RolloutStatus struct {
+	Name                     string                `json:"name"`
+	Namespace                string                `json:"namespace"`
+	Generation               int64                 `json:"generation"`
+	ObservedGeneration       int64                 `json:"observedGeneration"`
+	DesiredReplicas          int32                 `json:"desiredReplicas"`
+	Replicas                 int32                 `json:"replicas"`
+	UpdatedReplicas          int32                 `json:"updatedReplicas"`
+	ReadyReplicas            int32                 `json:"readyReplicas"`
+	AvailableReplicas        int32                 `json:"availableReplicas"`
+	UnavailableReplicas      int32                 `json:"unavailableReplicas"`
+	Paused                   bool                  `json:"paused"`
+	Conditions               []DeploymentCondition `json:"conditions"`
+	Done                     bool                  `json:"done"`
+	Failed                   bool                  `json:"failed"`
+	ProgressDeadlineExceeded bool                  `json:"progressDeadlineExceeded"`
+	Message                  string                `json:"message"`
+}
+
+// DeploymentCondition represents a deployment condition such as Progressing or Available
+type DeploymentCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastUpdateTime     string `json:"lastUpdateTime,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
 }
@@ -0,0 +1,40 @@
+package models
+
+// DrainNodeRequest represents the options for draining a node
+type DrainNodeRequest struct {
+	// GracePeriodSeconds overrides the termination grace period of evicted pods
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+	// TimeoutSeconds bounds the whole drain; defaults to 300
+	TimeoutSeconds int `json:"timeoutSeconds,omitempty"`
+	// Force also evicts pods that are not managed by a controller
+	Force bool `json:"force,omitempty"`
+	// DeleteEmptyDirData allows evicting pods that use emptyDir volumes
+	DeleteEmptyDirData bool `json:"deleteEmptyDirData,omitempty"`
+}
+
+// DrainJob represents the progress of a node drain running in the background
+type DrainJob struct {
+	ID   string `json:"id"`
+	Node string `json:"node"`
+	// Status is Running, Succeeded, Failed, TimedOut or Cancelled
+	Status       string     `json:"status"`
+	Message      string     `json:"message,omitempty"`
+	StartedAt    string     `json:"startedAt"`
+	FinishedAt   string     `json:"finishedAt,omitempty"`
+	Evicted      int        `json:"evicted"`
+	Pending      int        `json:"pending"`
+	BlockedByPDB int        `json:"blockedByPDB"`
+	Skipped      int        `json:"skipped"`
+	Pods         []DrainPod `json:"pods"`
+}
+
+// DrainPod represents the eviction state of a single pod during a drain
+type DrainPod struct {
+	Namespace string `json:"namespace"`
+	Name      string `json:"name"`
+	// Status is Pending, Evicting, Evicted, BlockedByPDB, Skipped or Failed
+	Status string `json:"status"`
+	Reason string `json:"reason,omitempty"`
+	// DisruptionBudgets lists the PodDisruptionBudgets blocking the eviction
+	DisruptionBudgets []string `json:"disruptionBudgets,omitempty"`
+}
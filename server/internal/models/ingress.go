@@ -0,0 +1,132 @@
+package models
+
+// Ingress represents a simplified ingress view with backends resolved to services
+type Ingress struct {
+	Name             string            `json:"name"`
+	Namespace        string            `json:"namespace"`
+	IngressClassName string            `json:"ingressClassName,omitempty"`
+	Hosts            []string          `json:"hosts"`
+	Rules            []IngressRule     `json:"rules"`
+	DefaultBackend   *IngressBackend   `json:"defaultBackend,omitempty"`
+	TLS              []IngressTLS      `json:"tls,omitempty"`
+	Addresses        []string          `json:"addresses"`
+	CreatedAt        string            `json:"createdAt"`
+	Labels           map[string]string `json:"labels,omitempty"`
+}
+
+// IngressRule represents the paths served for one host; an empty host matches all hosts
+type IngressRule struct {
+	Host  string        `json:"host"`
+	Paths []IngressPath `json:"paths"`
+}
+
+// IngressPath represents a path routed to a backend
+type IngressPath struct {
+	Path     string         `json:"path"`
+	PathType string         `json:"pathType"`
+	Backend  IngressBackend `json:"backend"`
+}
+
+// IngressBackend represents an ingress backend. Service is nil when the backend
+// is a resource reference or the referenced service does not exist.
+type IngressBackend struct {
+	ServiceName string   `json:"serviceName,omitempty"`
+	ServicePort string   `json:"servicePort,omitempty"`
+	Resource    string   `json:"resource,omitempty"`
+	Service     *Service `json:"service,omitempty"`
+	Missing     bool     `json:"missing"`
+}
+
+// IngressTLS represents the hosts covered by a TLS secret
+type IngressTLS struct {
+	Hosts      []string `json:"hosts"`
+	SecretName string   `json:"secretName"`
+}
+
+// IngressListResponse represents ingress list response
+type IngressListResponse struct {
+	Items []Ingress `json:"items"`
+}
+
+// CreateIngressRequest represents the request body for creating an ingress
+type CreateIngressRequest struct {
+	Name             string              `json:"name"`
+	Namespace        string              `json:"namespace"`
+	IngressClassName string              `json:"ingressClassName,omitempty"`
+	Labels           map[string]string   `json:"labels,omitempty"`
+	Annotations      map[string]string   `json:"annotations,omitempty"`
+	Rules            []IngressRuleSpec   `json:"rules,omitempty"`
+	DefaultBackend   *IngressBackendSpec `json:"defaultBackend,omitempty"`
+	TLS              []IngressTLS        `json:"tls,omitempty"`
+}
+
+// IngressRuleSpec represents a host rule in a create request
+type IngressRuleSpec struct {
+	Host  string            `json:"host,omitempty"`
+	Paths []IngressPathSpec `json:"paths"`
+}
+
+// IngressPathSpec represents a path in a create request; PathType defaults to Prefix
+type IngressPathSpec struct {
+	Path     string `json:"path"`
+	PathType string `json:"pathType,omitempty"`
+	IngressBackendSpec
+}
+
+// IngressBackendSpec references a service port by number or name
+type IngressBackendSpec struct {
+	ServiceName string `json:"serviceName"`
+	ServicePort string `json:"servicePort"`
+}
+
+// IngressRouteTree represents the host -> path -> service -> pods routing of an ingress
+type IngressRouteTree struct {
+	Name      string             `json:"name"`
+	Namespace string             `json:"namespace"`
+	Hosts     []IngressRouteHost `json:"hosts"`
+	// DefaultBackend receives requests that match no rule
+	DefaultBackend *IngressRoutePath `json:"defaultBackend,omitempty"`
+}
+
+// IngressRouteHost represents one host of the routing tree; "*" stands for any host
+type IngressRouteHost struct {
+	Host      string             `json:"host"`
+	TLSSecret string             `json:"tlsSecret,omitempty"`
+	Paths     []IngressRoutePath `json:"paths"`
+}
+
+// IngressRoutePath represents a path with the service and pods that serve it
+type IngressRoutePath struct {
+	Path     string         `json:"path"`
+	PathType string         `json:"pathType"`
+	Backend  IngressBackend `json:"backend"`
+	Pods     []RoutePod     `json:"pods"`
+}
+
+// RoutePod represents a pod selected by a service
+type RoutePod struct {
+	Name     string `json:"name"`
+	Ready    bool   `json:"ready"`
+	PodIP    string `json:"podIP,omitempty"`
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// IngressClass represents a simplified ingress class view
+type IngressClass struct {
+	Name       string `json:"name"`
+	Controller string `json:"controller"`
+	IsDefault  bool   `json:"isDefault"`
+	CreatedAt  string `json:"createdAt"`
+}
+
+// IngressClassListResponse represents ingress class list response
+type IngressClassListResponse struct {
+	Items []IngressClass `json:"items"`
+}
+
+// CreateIngressClassRequest represents the request body for creating an ingress class
+type CreateIngressClassRequest struct {
+	Name       string `json:"name"`
+	Controller string `json:"controller"`
+	IsDefault  bool   `json:"isDefault,omitempty"`
+}
@@ -0,0 +1,57 @@
+package models
+
+// Job represents a simplified job view
+type Job struct {
+	Name           string `json:"name"`
+	Namespace      string `json:"namespace"`
+	Status         string `json:"status"`
+	Completions    *int32 `json:"completions,omitempty"`
+	Parallelism    *int32 `json:"parallelism,omitempty"`
+	BackoffLimit   *int32 `json:"backoffLimit,omitempty"`
+	Active         int32  `json:"active"`
+	Succeeded      int32  `json:"succeeded"`
+	Failed         int32  `json:"failed"`
+	Suspended      bool   `json:"suspended"`
+	StartTime      string `json:"startTime,omitempty"`
+	CompletionTime string `json:"completionTime,omitempty"`
+	// Duration runs until completion, until the Failed condition for failed jobs, or until now
+	// for jobs that have not finished
+	Duration        string            `json:"duration,omitempty"`
+	DurationSeconds int64             `json:"durationSeconds"`
+	CronJob         string            `json:"cronJob,omitempty"`
+	Images          []string          `json:"images"`
+	CreatedAt       string            `json:"createdAt"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+// JobListResponse represents job list response
+type JobListResponse struct {
+	Items []Job `json:"items"`
+}
+
+// CronJob represents a simplified cronjob view
+type CronJob struct {
+	Name               string            `json:"name"`
+	Namespace          string            `json:"namespace"`
+	Schedule           string            `json:"schedule"`
+	TimeZone           string            `json:"timeZone,omitempty"`
+	Suspended          bool              `json:"suspended"`
+	ConcurrencyPolicy  string            `json:"concurrencyPolicy"`
+	Active             []string          `json:"active"`
+	LastScheduleTime   string            `json:"lastScheduleTime,omitempty"`
+	LastSuccessfulTime string            `json:"lastSuccessfulTime,omitempty"`
+	Images             []string          `json:"images"`
+	CreatedAt          string            `json:"createdAt"`
+	Labels             map[string]string `json:"labels,omitempty"`
+}
+
+// CronJobListResponse represents cronjob list response
+type CronJobListResponse struct {
+	Items []CronJob `json:"items"`
+}
+
+// TriggerCronJobRequest represents the optional request body for running a cronjob manually
+type TriggerCronJobRequest struct {
+	// JobName defaults to <cronjob>-manual-<timestamp>
+	JobName string `json:"jobName,omitempty"`
+}
@@ -11,40 +11,87 @@ type NodeMetrics struct {
 	Window    string             `json:"window"`
 	Available bool               `json:"available"`
 	Message   string             `json:"message,omitempty"`
+	// Network and Filesystem are only reported by providers that collect them, such as Prometheus
+	Network    *NetworkMetric      `json:"network,omitempty"`
+	Filesystem *NodeResourceMetric `json:"filesystem,omitempty"`
+	Source     string              `json:"source,omitempty"`
 }
 
-// NodeResourceMetric represents resource metric
+// NetworkMetric represents network throughput
+type NetworkMetric struct {
+	ReceiveBytesPerSecond  float64 `json:"receiveBytesPerSecond"`
+	TransmitBytesPerSecond float64 `json:"transmitBytesPerSecond"`
+}
+
+// NodeResourceMetric represents resource metric. For nodes, Percentage is usage relative to
+// allocatable, CapacityPercentage relative to capacity and RequestsPercentage the share of
+// allocatable committed to pod requests.
 type NodeResourceMetric struct {
-	Value      string  `json:"value"`
-	Quantity   int64   `json:"quantity"`
-	Percentage float64 `json:"percentage,omitempty"`
-	Unit       string  `json:"unit"`
+	Value              string  `json:"value"`
+	Quantity           int64   `json:"quantity"`
+	Percentage         float64 `json:"percentage,omitempty"`
+	CapacityPercentage float64 `json:"capacityPercentage,omitempty"`
+	Requests           string  `json:"requests,omitempty"`
+	RequestsPercentage float64 `json:"requestsPercentage,omitempty"`
+	Unit               string  `json:"unit"`
 }
 
 // PodMetrics represents pod metrics
 type PodMetrics struct {
-	PodName    string             `json:"podName"`
-	Namespace  string             `json:"namespace"`
-	Containers []ContainerMetrics `json:"containers"`
-	CPU        NodeResourceMetric `json:"cpu"`
-	Memory     NodeResourceMetric `json:"memory"`
-	Timestamp  time.Time          `json:"timestamp"`
-	Window     string             `json:"window"`
+	PodName    string              `json:"podName"`
+	Namespace  string              `json:"namespace"`
+	Containers []ContainerMetrics  `json:"containers"`
+	CPU        NodeResourceMetric  `json:"cpu"`
+	Memory     NodeResourceMetric  `json:"memory"`
+	Timestamp  time.Time           `json:"timestamp"`
+	Window     string              `json:"window"`
+	Network    *NetworkMetric      `json:"network,omitempty"`
+	Filesystem *NodeResourceMetric `json:"filesystem,omitempty"`
 }
 
 // ContainerMetrics represents container metrics
 type ContainerMetrics struct {
-	Name   string             `json:"name"`
-	CPU    NodeResourceMetric `json:"cpu"`
-	Memory NodeResourceMetric `json:"memory"`
+	Name       string              `json:"name"`
+	CPU        NodeResourceMetric  `json:"cpu"`
+	Memory     NodeResourceMetric  `json:"memory"`
+	Filesystem *NodeResourceMetric `json:"filesystem,omitempty"`
 }
 
-// NodeMetricsListResponse represents node metrics list response
+// NodeMetricsListResponse represents node metrics list response. Every node is listed;
+// Missing names the nodes the metrics API has no data for.
 type NodeMetricsListResponse struct {
-	Items []NodeMetrics `json:"items"`
+	Items   []NodeMetrics `json:"items"`
+	Missing []string      `json:"missing"`
+	// Message explains why every node is missing when the metrics provider could not be queried
+	Message string `json:"message,omitempty"`
+	Source  string `json:"source"`
 }
 
 // PodMetricsListResponse represents pod metrics list response
 type PodMetricsListResponse struct {
-	Items []PodMetrics `json:"items"`
+	Items  []PodMetrics `json:"items"`
+	Source string       `json:"source"`
+}
+
+// MetricsHistory represents the usage of a node, pod, namespace or deployment over a time range
+type MetricsHistory struct {
+	Kind      string         `json:"kind"`
+	Namespace string         `json:"namespace,omitempty"`
+	Name      string         `json:"name,omitempty"`
+	From      time.Time      `json:"from"`
+	To        time.Time      `json:"to"`
+	Step      string         `json:"step,omitempty"`
+	Source    string         `json:"source"`
+	Points    []MetricsPoint `json:"points"`
+}
+
+// MetricsPoint represents usage at a point in time; CPU is in millicores, Memory and Filesystem
+// in bytes and network throughput in bytes per second
+type MetricsPoint struct {
+	Timestamp       time.Time `json:"timestamp"`
+	CPU             int64     `json:"cpu"`
+	Memory          int64     `json:"memory"`
+	NetworkReceive  *float64  `json:"networkReceive,omitempty"`
+	NetworkTransmit *float64  `json:"networkTransmit,omitempty"`
+	Filesystem      *int64    `json:"filesystem,omitempty"`
 }
@@ -2,17 +2,108 @@ package models
 
 // Node represents a simplified node view
 type Node struct {
-	Name        string            `json:"name"`
-	Status      string            `json:"status"`
-	Version     string            `json:"version"`
-	OSImage     string            `json:"osImage"`
-	Capacity    map[string]string `json:"capacity"`
-	Allocatable map[string]string `json:"allocatable"`
-	CreatedAt   string            `json:"createdAt"`
-	Labels      map[string]string `json:"labels,omitempty"`
+	Name string `json:"name"`
+	// Status is Ready, NotReady or Unknown, taken from the Ready condition
+	Status                  string            `json:"status"`
+	Conditions              []NodeCondition   `json:"conditions"`
+	Pressures               []string          `json:"pressures,omitempty"`
+	Roles                   []string          `json:"roles"`
+	Addresses               []NodeAddress     `json:"addresses"`
+	InternalIP              string            `json:"internalIP,omitempty"`
+	ExternalIP              string            `json:"externalIP,omitempty"`
+	Version                 string            `json:"version"`
+	OSImage                 string            `json:"osImage"`
+	KernelVersion           string            `json:"kernelVersion"`
+	ContainerRuntimeVersion string            `json:"containerRuntimeVersion"`
+	Architecture            string            `json:"architecture"`
+	Capacity                map[string]string `json:"capacity"`
+	Allocatable             map[string]string `json:"allocatable"`
+	CreatedAt               string            `json:"createdAt"`
+	Labels                  map[string]string `json:"labels,omitempty"`
+	// Unschedulable is true while the node is cordoned
+	Unschedulable bool        `json:"unschedulable"`
+	Taints        []NodeTaint `json:"taints"`
+	// ResourceVersion can be sent back on taint and label updates for optimistic locking
+	ResourceVersion string `json:"resourceVersion"`
+	// Allocated sums the requests and limits of pods scheduled on the node; omitted when pods could not be listed
+	Allocated *NodeAllocatedResources `json:"allocated,omitempty"`
+}
+
+// NodeCondition represents a node condition such as Ready or MemoryPressure
+type NodeCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// NodeAddress represents an address of a node
+type NodeAddress struct {
+	Type    string `json:"type"`
+	Address string `json:"address"`
+}
+
+// NodeAllocatedResources compares the resources committed to pods on a node with its allocatable resources
+type NodeAllocatedResources struct {
+	CPU         AllocatedResource `json:"cpu"`
+	Memory      AllocatedResource `json:"memory"`
+	Pods        int               `json:"pods"`
+	PodCapacity int64             `json:"podCapacity"`
+}
+
+// AllocatedResource represents the requests and limits of one resource against allocatable
+type AllocatedResource struct {
+	Requests           string  `json:"requests"`
+	Limits             string  `json:"limits"`
+	Allocatable        string  `json:"allocatable"`
+	RequestsPercentage float64 `json:"requestsPercentage"`
+	LimitsPercentage   float64 `json:"limitsPercentage"`
+}
+
+// NodeTaint represents a taint on a node
+type NodeTaint struct {
+	Key   string `json:"key"`
+	Value string `json:"value,omitempty"`
+	// Effect is NoSchedule, PreferNoSchedule or NoExecute
+	Effect    string `json:"effect"`
+	TimeAdded string `json:"timeAdded,omitempty"`
 }
 
 // NodeListResponse represents node list response
 type NodeListResponse struct {
 	Items []Node `json:"items"`
-} 
\ No newline at end of file
+}
+
+// UpdateNodeTaintsRequest adds, modifies and removes node taints. Taints in Set replace the
+// taint with the same key and effect; Remove matches by key and, when given, effect.
+type UpdateNodeTaintsRequest struct {
+	ResourceVersion string      `json:"resourceVersion,omitempty"`
+	Set             []NodeTaint `json:"set,omitempty"`
+	Remove          []NodeTaint `json:"remove,omitempty"`
+}
+
+// UpdateNodeLabelsRequest sets and removes node labels
+type UpdateNodeLabelsRequest struct {
+	ResourceVersion string            `json:"resourceVersion,omitempty"`
+	Set             map[string]string `json:"set,omitempty"`
+	Remove          []string          `json:"remove,omitempty"`
+}
+
+// NodePod represents a pod scheduled on a node with its requests, limits and live usage
+type NodePod struct {
+	Pod
+	Requests map[string]string `json:"requests,omitempty"`
+	Limits   map[string]string `json:"limits,omitempty"`
+	// CPU and Memory hold usage from the metrics API; nil when the pod has no metrics
+	CPU    *NodeResourceMetric `json:"cpu,omitempty"`
+	Memory *NodeResourceMetric `json:"memory,omitempty"`
+}
+
+// NodePodListResponse represents the pods running on a node, highest CPU usage first
+type NodePodListResponse struct {
+	Node             string    `json:"node"`
+	MetricsAvailable bool      `json:"metricsAvailable"`
+	Message          string    `json:"message,omitempty"`
+	Items            []NodePod `json:"items"`
+}
@@ -5,6 +5,8 @@ type Pod struct {
 	Name         string            `json:"name"`
 	Namespace    string            `json:"namespace"`
 	Status       string            `json:"status"`
+	Phase        string            `json:"phase"`
+	Ready        string            `json:"ready"`
 	RestartCount int32             `json:"restartCount"`
 	CreatedAt    string            `json:"createdAt"`
 	NodeName     string            `json:"nodeName"`
@@ -16,4 +18,125 @@ type Pod struct {
 // PodListResponse represents pod list response
 type PodListResponse struct {
 	Items []Pod `json:"items"`
-} 
\ No newline at end of file
+}
+
+// EvictPodRequest represents the optional request body for evicting a pod
+type EvictPodRequest struct {
+	GracePeriodSeconds *int64 `json:"gracePeriodSeconds,omitempty"`
+}
+
+// PodFile represents a file copied into a container
+type PodFile struct {
+	Name string `json:"name"`
+	Size int64  `json:"size"`
+}
+
+// PodFileUploadResponse represents the result of uploading files to a container
+type PodFileUploadResponse struct {
+	Container string    `json:"container"`
+	Path      string    `json:"path"`
+	Files     []PodFile `json:"files"`
+}
+
+// PodDetail represents a detailed pod view returned for a single pod
+type PodDetail struct {
+	Pod
+	HostIP                string            `json:"hostIP,omitempty"`
+	QOSClass              string            `json:"qosClass"`
+	ServiceAccount        string            `json:"serviceAccount,omitempty"`
+	Annotations           map[string]string `json:"annotations,omitempty"`
+	InitContainerStatuses []ContainerDetail `json:"initContainerStatuses,omitempty"`
+	ContainerStatuses     []ContainerDetail `json:"containerStatuses"`
+	Conditions            []PodCondition    `json:"conditions"`
+	Volumes               []PodVolume       `json:"volumes,omitempty"`
+	OwnerReferences       []OwnerReference  `json:"ownerReferences,omitempty"`
+	Tolerations           []Toleration      `json:"tolerations,omitempty"`
+}
+
+// ContainerDetail represents the spec and runtime status of a single container
+type ContainerDetail struct {
+	Name           string            `json:"name"`
+	Image          string            `json:"image"`
+	ImageID        string            `json:"imageID,omitempty"`
+	Ready          bool              `json:"ready"`
+	Started        bool              `json:"started"`
+	RestartCount   int32             `json:"restartCount"`
+	State          ContainerState    `json:"state"`
+	LastState      *ContainerState   `json:"lastState,omitempty"`
+	Requests       map[string]string `json:"requests,omitempty"`
+	Limits         map[string]string `json:"limits,omitempty"`
+	Ports          []ContainerPort   `json:"ports,omitempty"`
+	VolumeMounts   []VolumeMount     `json:"volumeMounts,omitempty"`
+	LivenessProbe  *Probe            `json:"livenessProbe,omitempty"`
+	ReadinessProbe *Probe            `json:"readinessProbe,omitempty"`
+	StartupProbe   *Probe            `json:"startupProbe,omitempty"`
+}
+
+// ContainerState represents a container state (waiting, running or terminated)
+type ContainerState struct {
+	State      string `json:"state"`
+	Reason     string `json:"reason,omitempty"`
+	Message    string `json:"message,omitempty"`
+	ExitCode   *int32 `json:"exitCode,omitempty"`
+	Signal     int32  `json:"signal,omitempty"`
+	StartedAt  string `json:"startedAt,omitempty"`
+	FinishedAt string `json:"finishedAt,omitempty"`
+}
+
+// ContainerPort represents a port exposed by a container
+type ContainerPort struct {
+	Name          string `json:"name,omitempty"`
+	ContainerPort int32  `json:"containerPort"`
+	Protocol      string `json:"protocol"`
+}
+
+// VolumeMount represents a volume mounted into a container
+type VolumeMount struct {
+	Name      string `json:"name"`
+	MountPath string `json:"mountPath"`
+	SubPath   string `json:"subPath,omitempty"`
+	ReadOnly  bool   `json:"readOnly"`
+}
+
+// Probe represents a liveness, readiness or startup probe
+type Probe struct {
+	Type                string `json:"type"`
+	Handler             string `json:"handler"`
+	InitialDelaySeconds int32  `json:"initialDelaySeconds"`
+	PeriodSeconds       int32  `json:"periodSeconds"`
+	TimeoutSeconds      int32  `json:"timeoutSeconds"`
+	SuccessThreshold    int32  `json:"successThreshold"`
+	FailureThreshold    int32  `json:"failureThreshold"`
+}
+
+// PodCondition represents a pod condition such as Ready or PodScheduled
+type PodCondition struct {
+	Type               string `json:"type"`
+	Status             string `json:"status"`
+	Reason             string `json:"reason,omitempty"`
+	Message            string `json:"message,omitempty"`
+	LastTransitionTime string `json:"lastTransitionTime,omitempty"`
+}
+
+// PodVolume represents a pod volume and its source
+type PodVolume struct {
+	Name   string `json:"name"`
+	Type   string `json:"type"`
+	Source string `json:"source,omitempty"`
+}
+
+// OwnerReference represents the controller or owner of an object
+type OwnerReference struct {
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	Controller bool   `json:"controller"`
+}
+
+// Toleration represents a pod toleration
+type Toleration struct {
+	Key               string `json:"key,omitempty"`
+	Operator          string `json:"operator,omitempty"`
+	Value             string `json:"value,omitempty"`
+	Effect            string `json:"effect,omitempty"`
+	TolerationSeconds *int64 `json:"tolerationSeconds,omitempty"`
+}
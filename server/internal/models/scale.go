@@ -0,0 +1,19 @@
+package models
+
+// Scale represents the scale subresource of a workload
+type Scale struct {
+	Kind            string `json:"kind"`
+	Name            string `json:"name"`
+	Namespace       string `json:"namespace"`
+	DesiredReplicas int32  `json:"desiredReplicas"`
+	CurrentReplicas int32  `json:"currentReplicas"`
+	Selector        string `json:"selector"`
+	ResourceVersion string `json:"resourceVersion"`
+}
+
+// ScaleRequest represents the request body for scaling a workload.
+// When ResourceVersion is set the update is rejected with 409 if the workload changed since.
+type ScaleRequest struct {
+	Replicas        *int32 `json:"replicas"`
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
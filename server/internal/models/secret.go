@@ -0,0 +1,80 @@
+package models
+
+// Secret represents a simplified secret view; values are never included
+type Secret struct {
+	Name            string            `json:"name"`
+	Namespace       string            `json:"namespace"`
+	Type            string            `json:"type"`
+	Keys            []SecretKey       `json:"keys"`
+	Immutable       bool              `json:"immutable"`
+	ResourceVersion string            `json:"resourceVersion"`
+	CreatedAt       string            `json:"createdAt"`
+	Labels          map[string]string `json:"labels,omitempty"`
+}
+
+// SecretKey represents a secret key and the size of its value in bytes
+type SecretKey struct {
+	Name string `json:"name"`
+	Size int    `json:"size"`
+}
+
+// SecretListResponse represents secret list response
+type SecretListResponse struct {
+	Items []Secret `json:"items"`
+}
+
+// CreateSecretRequest represents the request body for creating a secret.
+// Type is one of generic (default), docker-registry or tls, as with kubectl create secret.
+type CreateSecretRequest struct {
+	Name      string            `json:"name"`
+	Namespace string            `json:"namespace"`
+	Type      string            `json:"type,omitempty"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	Immutable bool              `json:"immutable,omitempty"`
+
+	// generic: plain-text values and base64-encoded binary values
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+
+	// docker-registry
+	Registry string `json:"registry,omitempty"`
+	Username string `json:"username,omitempty"`
+	Password string `json:"password,omitempty"`
+	Email    string `json:"email,omitempty"`
+
+	// tls: PEM-encoded certificate chain and private key
+	Cert string `json:"cert,omitempty"`
+	Key  string `json:"key,omitempty"`
+}
+
+// UpdateSecretRequest sets and removes individual keys, leaving other keys untouched
+type UpdateSecretRequest struct {
+	Data       map[string]string `json:"data,omitempty"`
+	BinaryData map[string][]byte `json:"binaryData,omitempty"`
+	RemoveKeys []string          `json:"removeKeys,omitempty"`
+	// Labels are left untouched when omitted
+	Labels map[string]string `json:"labels,omitempty"`
+	// ResourceVersion, when set, makes the update fail with 409 if the secret changed since it was read
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// RevealSecretRequest represents the optional request body for revealing secret values
+type RevealSecretRequest struct {
+	// Keys limits the reveal to the given keys; all keys are revealed when empty
+	Keys []string `json:"keys,omitempty"`
+}
+
+// RevealedSecret represents the decoded values of a secret
+type RevealedSecret struct {
+	Name      string        `json:"name"`
+	Namespace string        `json:"namespace"`
+	Type      string        `json:"type"`
+	Values    []SecretValue `json:"values"`
+}
+
+// SecretValue represents a single secret value. Encoding is "text" for valid UTF-8 and "base64" otherwise.
+type SecretValue struct {
+	Key      string `json:"key"`
+	Value    string `json:"value"`
+	Encoding string `json:"encoding"`
+}
@@ -2,18 +2,64 @@ package models
 
 // Service represents a simplified service view
 type Service struct {
-	Name      string        `json:"name"`
-	Namespace string        `json:"namespace"`
-	Type      string        `json:"type"`
-	ClusterIP string        `json:"clusterIP"`
-	Ports     []ServicePort `json:"ports"`
-	CreatedAt string        `json:"createdAt"`
+	Name                  string            `json:"name"`
+	Namespace             string            `json:"namespace"`
+	Type                  string            `json:"type"`
+	ClusterIP             string            `json:"clusterIP"`
+	Headless              bool              `json:"headless"`
+	ExternalName          string            `json:"externalName,omitempty"`
+	Ports                 []ServicePort     `json:"ports"`
+	Selector              map[string]string `json:"selector,omitempty"`
+	SessionAffinity       string            `json:"sessionAffinity,omitempty"`
+	ExternalTrafficPolicy string            `json:"externalTrafficPolicy,omitempty"`
+	LoadBalancerAddresses []string          `json:"loadBalancerAddresses,omitempty"`
+	ResourceVersion       string            `json:"resourceVersion"`
+	CreatedAt             string            `json:"createdAt"`
+	Labels                map[string]string `json:"labels,omitempty"`
+	// MatchedPods and ReadyPods count the pods selected by the service, when it has a selector
+	MatchedPods *int `json:"matchedPods,omitempty"`
+	ReadyPods   *int `json:"readyPods,omitempty"`
+	// Warning is NoMatchingPods or NoReadyPods when the selector leaves the service without backends
+	Warning string `json:"warning,omitempty"`
 }
 
 // ServicePort represents service port information
 type ServicePort struct {
-	Port     int32  `json:"port"`
-	Protocol string `json:"protocol"`
+	Name       string `json:"name,omitempty"`
+	Port       int32  `json:"port"`
+	TargetPort string `json:"targetPort,omitempty"`
+	NodePort   int32  `json:"nodePort,omitempty"`
+	Protocol   string `json:"protocol"`
+}
+
+// ServiceEndpointsResponse lists the EndpointSlices backing a service
+type ServiceEndpointsResponse struct {
+	Service       string                 `json:"service"`
+	Namespace     string                 `json:"namespace"`
+	ReadyCount    int                    `json:"readyCount"`
+	NotReadyCount int                    `json:"notReadyCount"`
+	Warning       string                 `json:"warning,omitempty"`
+	Slices        []ServiceEndpointSlice `json:"slices"`
+}
+
+// ServiceEndpointSlice represents one EndpointSlice of a service
+type ServiceEndpointSlice struct {
+	Name        string            `json:"name"`
+	AddressType string            `json:"addressType"`
+	Ports       []ServicePort     `json:"ports"`
+	Ready       []EndpointAddress `json:"ready"`
+	NotReady    []EndpointAddress `json:"notReady"`
+}
+
+// EndpointAddress represents an endpoint mapped to the pod and node behind it
+type EndpointAddress struct {
+	Addresses   []string `json:"addresses"`
+	PodName     string   `json:"podName,omitempty"`
+	NodeName    string   `json:"nodeName,omitempty"`
+	Zone        string   `json:"zone,omitempty"`
+	Hostname    string   `json:"hostname,omitempty"`
+	Serving     bool     `json:"serving"`
+	Terminating bool     `json:"terminating"`
 }
 
 // ServiceListResponse represents service list response
@@ -23,8 +69,45 @@ type ServiceListResponse struct {
 
 // CreateServiceRequest represents the request body for creating a service
 type CreateServiceRequest struct {
-	Name       string `json:"name"`
-	Namespace  string `json:"namespace"`
-	Port       int32  `json:"port"`
-	TargetPort int32  `json:"targetPort"`
+	Name      string `json:"name"`
+	Namespace string `json:"namespace"`
+	// Port and TargetPort are a shorthand for a single TCP port when Ports is empty
+	Port       int32 `json:"port,omitempty"`
+	TargetPort int32 `json:"targetPort,omitempty"`
+	ServiceSpec
+}
+
+// UpdateServiceRequest replaces the spec of an existing service
+type UpdateServiceRequest struct {
+	ServiceSpec
+	// ResourceVersion, when set, makes the update fail with 409 if the service changed since it was read
+	ResourceVersion string `json:"resourceVersion,omitempty"`
+}
+
+// ServiceSpec represents the editable parts of a service
+type ServiceSpec struct {
+	// Type is ClusterIP (default), NodePort, LoadBalancer or ExternalName
+	Type string `json:"type,omitempty"`
+	// Headless creates a ClusterIP service without a cluster IP
+	Headless     bool              `json:"headless,omitempty"`
+	ExternalName string            `json:"externalName,omitempty"`
+	Ports        []ServicePortSpec `json:"ports,omitempty"`
+	// Selector defaults to app=<name> on create, except for ExternalName services
+	Selector                      map[string]string `json:"selector,omitempty"`
+	SessionAffinity               string            `json:"sessionAffinity,omitempty"`
+	SessionAffinityTimeoutSeconds *int32            `json:"sessionAffinityTimeoutSeconds,omitempty"`
+	ExternalTrafficPolicy         string            `json:"externalTrafficPolicy,omitempty"`
+	Labels                        map[string]string `json:"labels,omitempty"`
+	Annotations                   map[string]string `json:"annotations,omitempty"`
+}
+
+// ServicePortSpec represents a service port in a create or update request
+type ServicePortSpec struct {
+	Name string `json:"name,omitempty"`
+	Port int32  `json:"port"`
+	// TargetPort is a container port number or name; defaults to Port
+	TargetPort string `json:"targetPort,omitempty"`
+	// NodePort is allocated automatically when omitted
+	NodePort int32  `json:"nodePort,omitempty"`
+	Protocol string `json:"protocol,omitempty"`
 }
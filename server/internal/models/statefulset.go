@@ -0,0 +1,72 @@
+package models
+
+// StatefulSet represents a simplified statefulset view
+type StatefulSet struct {
+	Name                 string                `json:"name"`
+	Namespace            string                `json:"namespace"`
+	Replicas             int32                 `json:"replicas"`
+	ReadyReplicas        int32                 `json:"readyReplicas"`
+	CurrentReplicas      int32                 `json:"currentReplicas"`
+	UpdatedReplicas      int32                 `json:"updatedReplicas"`
+	AvailableReplicas    int32                 `json:"availableReplicas"`
+	ServiceName          string                `json:"serviceName"`
+	PodManagementPolicy  string                `json:"podManagementPolicy"`
+	UpdateStrategy       string                `json:"updateStrategy"`
+	Partition            *int32                `json:"partition,omitempty"`
+	CurrentRevision      string                `json:"currentRevision"`
+	UpdateRevision       string                `json:"updateRevision"`
+	Images               []string              `json:"images"`
+	VolumeClaimTemplates []VolumeClaimTemplate `json:"volumeClaimTemplates,omitempty"`
+	CreatedAt            string                `json:"createdAt"`
+	RestartedAt          string                `json:"restartedAt,omitempty"`
+	Labels               map[string]string     `json:"labels,omitempty"`
+	// Ordinals is only populated when fetching a single statefulset
+	Ordinals []StatefulSetOrdinal `json:"ordinals,omitempty"`
+}
+
+// StatefulSetOrdinal represents the pod backing one ordinal of a statefulset
+type StatefulSetOrdinal struct {
+	Ordinal  int    `json:"ordinal"`
+	Pod      string `json:"pod"`
+	Exists   bool   `json:"exists"`
+	Ready    bool   `json:"ready"`
+	Status   string `json:"status,omitempty"`
+	Revision string `json:"revision,omitempty"`
+	Updated  bool   `json:"updated"`
+	NodeName string `json:"nodeName,omitempty"`
+}
+
+// VolumeClaimTemplate represents a persistent volume claim template of a statefulset
+type VolumeClaimTemplate struct {
+	Name         string   `json:"name"`
+	StorageClass string   `json:"storageClass,omitempty"`
+	AccessModes  []string `json:"accessModes,omitempty"`
+	Storage      string   `json:"storage"`
+}
+
+// StatefulSetListResponse represents statefulset list response
+type StatefulSetListResponse struct {
+	Items []StatefulSet `json:"items"`
+}
+
+// CreateStatefulSetRequest represents the request body for creating a statefulset
+type CreateStatefulSetRequest struct {
+	Name        string            `json:"name"`
+	Namespace   string            `json:"namespace"`
+	Replicas    int32             `json:"replicas"`
+	ServiceName string            `json:"serviceName"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+	// Selector defaults to Labels, or app=<name> when no labels are given
+	Selector map[string]string `json:"selector,omitempty"`
+	PodTemplateOptions
+	PodManagementPolicy  string                `json:"podManagementPolicy,omitempty"`
+	UpdateStrategy       *StatefulSetStrategy  `json:"updateStrategy,omitempty"`
+	VolumeClaimTemplates []VolumeClaimTemplate `json:"volumeClaimTemplates,omitempty"`
+}
+
+// StatefulSetStrategy represents statefulset update strategy parameters
+type StatefulSetStrategy struct {
+	Type      string `json:"type"`
+	Partition *int32 `json:"partition,omitempty"`
+}
@@ -0,0 +1,13 @@
+package models
+
+// FieldError represents a validation error for a single request field
+type FieldError struct {
+	Field   string `json:"field"`
+	Message string `json:"message"`
+}
+
+// ValidationErrorResponse represents a request rejected because of invalid fields
+type ValidationErrorResponse struct {
+	Message string       `json:"message"`
+	Errors  []FieldError `json:"errors"`
+}
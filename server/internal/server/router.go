@@ -1,22 +1,28 @@
 package server
 
 import (
+	"context"
 	"encoding/json"
+	"log"
 	"net/http"
+	"os"
 	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/rs/cors"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 
 	"k8_gui/internal/auth"
+	"k8_gui/internal/history"
+	"k8_gui/internal/metricsource"
 	"k8_gui/internal/server/routes"
 	"k8_gui/internal/utils"
 )
 
 // NewRouter creates application router
-func NewRouter(clientset *kubernetes.Clientset, metricsClient *metricsclientset.Clientset) http.Handler {
+func NewRouter(ctx context.Context, clientset *kubernetes.Clientset, metricsClient *metricsclientset.Clientset, restConfig *rest.Config) http.Handler {
 	router := mux.NewRouter()
 
 	// Public routes
@@ -28,15 +34,24 @@ func NewRouter(clientset *kubernetes.Clientset, metricsClient *metricsclientset.
 
 	if clientset != nil {
 		// Register grouped routes
-		routes.RegisterPodRoutes(protected, clientset)
+		routes.RegisterPodRoutes(protected, clientset, restConfig)
 		routes.RegisterDeploymentRoutes(protected, clientset)
+		routes.RegisterStatefulSetRoutes(protected, clientset)
+		routes.RegisterDaemonSetRoutes(protected, clientset)
+		routes.RegisterJobRoutes(protected, clientset)
+		routes.RegisterScaleRoutes(protected, clientset)
 		routes.RegisterServiceRoutes(protected, clientset)
+		routes.RegisterIngressRoutes(protected, clientset)
+		routes.RegisterConfigMapRoutes(protected, clientset)
+		routes.RegisterSecretRoutes(protected, clientset)
 		routes.RegisterEventRoutes(protected, clientset)
-		routes.RegisterNodeRoutes(protected, clientset, metricsClient)
+		provider := newMetricsProvider(ctx, clientset, metricsClient)
+		routes.RegisterNodeRoutes(protected, clientset, provider)
 		routes.RegisterNamspaceRoutes(protected, clientset)
 
-		if metricsClient != nil {
-			routes.RegisterMetricsRoutes(protected, metricsClient)
+		if provider != nil {
+			routes.RegisterMetricsRoutes(protected, clientset, provider)
+			routes.RegisterMetricsHistoryRoutes(protected, provider)
 		}
 	} else {
 		// Add mock routes if Kubernetes is unavailable
@@ -107,10 +122,40 @@ func NewRouter(clientset *kubernetes.Clientset, metricsClient *metricsclientset.
 	// Enable CORS
 	corsHandler := cors.New(cors.Options{
 		AllowedOrigins:   []string{"*"},
-		AllowedMethods:   []string{"GET", "POST", "PUT", "DELETE", "OPTIONS"},
+		AllowedMethods:   []string{"GET", "POST", "PUT", "PATCH", "DELETE", "OPTIONS"},
 		AllowedHeaders:   []string{"Content-Type", "Authorization"},
 		AllowCredentials: true,
 	})
 
 	return corsHandler.Handler(router)
 }
+
+// newMetricsProvider returns Prometheus when PROMETHEUS_URL is set and metrics-server otherwise,
+// or nil when neither is available. Background history recording stops when ctx is done.
+func newMetricsProvider(ctx context.Context, clientset *kubernetes.Clientset, metricsClient *metricsclientset.Clientset) metricsource.Provider {
+	if os.Getenv("PROMETHEUS_URL") != "" {
+		config, err := metricsource.PrometheusConfigFromEnv()
+		if err == nil {
+			var provider *metricsource.Prometheus
+			if provider, err = metricsource.NewPrometheus(config); err == nil {
+				log.Printf(utils.LogMetricsProvider, provider.Name())
+				return provider
+			}
+		}
+		log.Printf(utils.LogFailedInitPrometheus, err)
+	}
+
+	if metricsClient == nil {
+		return nil
+	}
+
+	// Record usage in the background so charts survive reloads; Prometheus keeps its own history
+	store := history.NewStore(history.ConfigFromEnv())
+	history.NewScraper(store, clientset, metricsClient).Start(ctx)
+	config := store.Config()
+	log.Printf(utils.LogMetricsHistoryStarted, config.Interval, config.Retention, config.RawRetention, config.Resolution)
+
+	provider := metricsource.NewMetricsServer(metricsClient, store)
+	log.Printf(utils.LogMetricsProvider, provider.Name())
+	return provider
+}
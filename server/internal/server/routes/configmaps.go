@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"k8s.io/client-go/kubernetes"
+	"k8_gui/internal/api"
+)
+
+func RegisterConfigMapRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
+	r.HandleFunc("/configmaps", api.ListConfigMaps(clientset)).Methods("GET")
+	r.HandleFunc("/configmaps/{namespace}/{name}", api.GetConfigMap(clientset)).Methods("GET")
+	r.HandleFunc("/configmaps", api.CreateConfigMap(clientset)).Methods("POST")
+	r.HandleFunc("/configmaps/{namespace}/{name}", api.UpdateConfigMap(clientset)).Methods("PUT")
+	r.HandleFunc("/configmaps/{namespace}/{name}", api.DeleteConfigMap(clientset)).Methods("DELETE")
+}
@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"k8s.io/client-go/kubernetes"
+	"k8_gui/internal/api"
+)
+
+func RegisterDaemonSetRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
+	r.HandleFunc("/daemonsets", api.ListDaemonSets(clientset)).Methods("GET")
+	r.HandleFunc("/daemonsets/{namespace}/{name}", api.GetDaemonSet(clientset)).Methods("GET")
+	r.HandleFunc("/daemonsets/{namespace}/{name}", api.DeleteDaemonSet(clientset)).Methods("DELETE")
+	r.HandleFunc("/daemonsets/{namespace}/{name}/nodes", api.GetDaemonSetNodes(clientset)).Methods("GET")
+	r.HandleFunc("/daemonsets/{namespace}/{name}/restart", api.RestartDaemonSet(clientset)).Methods("POST")
+}
@@ -12,4 +12,12 @@ func RegisterDeploymentRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
 	r.HandleFunc("/deployments/{namespace}/{name}", api.DeleteDeployment(clientset)).Methods("DELETE")
 	r.HandleFunc("/deployments", api.CreateDeployment(clientset)).Methods("POST")
 	r.HandleFunc("/deployments/{namespace}/{name}", api.UpdateDeployment(clientset)).Methods("PUT")
+	r.HandleFunc("/deployments/{namespace}/{name}", api.PatchDeployment(clientset)).Methods("PATCH")
+	r.HandleFunc("/deployments/{namespace}/{name}/restart", api.RestartDeployment(clientset)).Methods("POST")
+	r.HandleFunc("/deployments/{namespace}/{name}/pause", api.PauseDeployment(clientset)).Methods("POST")
+	r.HandleFunc("/deployments/{namespace}/{name}/resume", api.ResumeDeployment(clientset)).Methods("POST")
+	r.HandleFunc("/deployments/{namespace}/{name}/history", api.GetDeploymentHistory(clientset)).Methods("GET")
+	r.HandleFunc("/deployments/{namespace}/{name}/rollback", api.RollbackDeployment(clientset)).Methods("POST")
+	r.HandleFunc("/deployments/{namespace}/{name}/rollout-status", api.GetDeploymentRolloutStatus(clientset)).Methods("GET")
+	r.HandleFunc("/deployments/{namespace}/{name}/rollout-status/stream", api.StreamDeploymentRolloutStatus(clientset)).Methods("GET")
 }
@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"k8s.io/client-go/kubernetes"
+	"k8_gui/internal/api"
+)
+
+func RegisterIngressRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
+	r.HandleFunc("/ingresses", api.ListIngresses(clientset)).Methods("GET")
+	r.HandleFunc("/ingresses/{namespace}/{name}", api.GetIngress(clientset)).Methods("GET")
+	r.HandleFunc("/ingresses/{namespace}/{name}/routes", api.GetIngressRoutes(clientset)).Methods("GET")
+	r.HandleFunc("/ingresses", api.CreateIngress(clientset)).Methods("POST")
+	r.HandleFunc("/ingresses/{namespace}/{name}", api.DeleteIngress(clientset)).Methods("DELETE")
+
+	r.HandleFunc("/ingressclasses", api.ListIngressClasses(clientset)).Methods("GET")
+	r.HandleFunc("/ingressclasses/{name}", api.GetIngressClass(clientset)).Methods("GET")
+	r.HandleFunc("/ingressclasses", api.CreateIngressClass(clientset)).Methods("POST")
+	r.HandleFunc("/ingressclasses/{name}", api.DeleteIngressClass(clientset)).Methods("DELETE")
+}
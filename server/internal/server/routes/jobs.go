@@ -0,0 +1,20 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"k8s.io/client-go/kubernetes"
+	"k8_gui/internal/api"
+)
+
+func RegisterJobRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
+	r.HandleFunc("/jobs", api.ListJobs(clientset)).Methods("GET")
+	r.HandleFunc("/jobs/{namespace}/{name}", api.GetJob(clientset)).Methods("GET")
+	r.HandleFunc("/jobs/{namespace}/{name}", api.DeleteJob(clientset)).Methods("DELETE")
+
+	r.HandleFunc("/cronjobs", api.ListCronJobs(clientset)).Methods("GET")
+	r.HandleFunc("/cronjobs/{namespace}/{name}", api.GetCronJob(clientset)).Methods("GET")
+	r.HandleFunc("/cronjobs/{namespace}/{name}", api.DeleteCronJob(clientset)).Methods("DELETE")
+	r.HandleFunc("/cronjobs/{namespace}/{name}/suspend", api.SuspendCronJob(clientset)).Methods("POST")
+	r.HandleFunc("/cronjobs/{namespace}/{name}/resume", api.ResumeCronJob(clientset)).Methods("POST")
+	r.HandleFunc("/cronjobs/{namespace}/{name}/trigger", api.TriggerCronJob(clientset)).Methods("POST")
+}
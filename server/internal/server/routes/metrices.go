@@ -2,12 +2,20 @@ package routes
 
 import (
 	"github.com/gorilla/mux"
-	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
+	"k8s.io/client-go/kubernetes"
 	"k8_gui/internal/api"
+	"k8_gui/internal/metricsource"
 )
 
-func RegisterMetricsRoutes(r *mux.Router, metricsClient *metricsclientset.Clientset) {
-	r.HandleFunc("/metrics/nodes", api.GetNodesMetrics(metricsClient)).Methods("GET")
-	r.HandleFunc("/metrics/pods", api.GetPodsMetrics(metricsClient)).Methods("GET")
-	r.HandleFunc("/metrics/pods/{namespace}", api.GetPodMetricsByNamespace(metricsClient)).Methods("GET")
+func RegisterMetricsRoutes(r *mux.Router, clientset *kubernetes.Clientset, provider metricsource.Provider) {
+	r.HandleFunc("/metrics/nodes", api.GetNodesMetrics(clientset, provider)).Methods("GET")
+	r.HandleFunc("/metrics/pods", api.GetPodsMetrics(provider)).Methods("GET")
+	r.HandleFunc("/metrics/pods/{namespace}", api.GetPodMetricsByNamespace(provider)).Methods("GET")
+}
+
+func RegisterMetricsHistoryRoutes(r *mux.Router, provider metricsource.Provider) {
+	r.HandleFunc("/metrics/nodes/{name}/history", api.GetNodeMetricsHistory(provider)).Methods("GET")
+	r.HandleFunc("/metrics/pods/{namespace}/{name}/history", api.GetPodMetricsHistory(provider)).Methods("GET")
+	r.HandleFunc("/metrics/namespaces/{namespace}/history", api.GetNamespaceMetricsHistory(provider)).Methods("GET")
+	r.HandleFunc("/metrics/deployments/{namespace}/{name}/history", api.GetDeploymentMetricsHistory(provider)).Methods("GET")
 }
@@ -3,15 +3,24 @@ package routes
 import (
 	"github.com/gorilla/mux"
 	"k8s.io/client-go/kubernetes"
-	metricsclientset "k8s.io/metrics/pkg/client/clientset/versioned"
 	"k8_gui/internal/api"
+	"k8_gui/internal/metricsource"
 )
 
-func RegisterNodeRoutes(r *mux.Router, clientset *kubernetes.Clientset, metricsClient *metricsclientset.Clientset) {
+func RegisterNodeRoutes(r *mux.Router, clientset *kubernetes.Clientset, provider metricsource.Provider) {
 	r.HandleFunc("/nodes", api.ListNodes(clientset)).Methods("GET")
 	r.HandleFunc("/nodes/{name}", api.GetNode(clientset)).Methods("GET")
+	r.HandleFunc("/nodes/{name}/pods", api.GetNodePods(clientset, provider)).Methods("GET")
+	r.HandleFunc("/nodes/{name}/cordon", api.CordonNode(clientset)).Methods("POST")
+	r.HandleFunc("/nodes/{name}/uncordon", api.UncordonNode(clientset)).Methods("POST")
+	r.HandleFunc("/nodes/{name}/taints", api.UpdateNodeTaints(clientset)).Methods("PATCH")
+	r.HandleFunc("/nodes/{name}/labels", api.UpdateNodeLabels(clientset)).Methods("PATCH")
+	r.HandleFunc("/nodes/{name}/drain", api.DrainNode(clientset)).Methods("POST")
+	r.HandleFunc("/nodes/{name}/drain", api.GetNodeDrain()).Methods("GET")
+	r.HandleFunc("/nodes/{name}/drain/stream", api.StreamNodeDrain()).Methods("GET")
+	r.HandleFunc("/nodes/{name}/drain", api.CancelNodeDrain()).Methods("DELETE")
 
-	if metricsClient != nil {
-		r.HandleFunc("/nodes/{name}/metrics", api.GetNodeMetrics(clientset, metricsClient)).Methods("GET")
+	if provider != nil {
+		r.HandleFunc("/nodes/{name}/metrics", api.GetNodeMetrics(clientset, provider)).Methods("GET")
 	}
 }
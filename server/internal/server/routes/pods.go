@@ -3,13 +3,17 @@ package routes
 import (
 	"github.com/gorilla/mux"
 	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
 	// "k8s.io/metrics/pkg/client/clientset/versioned"
 	"k8_gui/internal/api"
 )
 
-func RegisterPodRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
+func RegisterPodRoutes(r *mux.Router, clientset *kubernetes.Clientset, restConfig *rest.Config) {
 	r.HandleFunc("/pods", api.ListPods(clientset)).Methods("GET")
 	r.HandleFunc("/pods/{namespace}/{name}", api.GetPod(clientset)).Methods("GET")
 	r.HandleFunc("/pods/{namespace}/{name}", api.DeletePod(clientset)).Methods("DELETE")
+	r.HandleFunc("/pods/{namespace}/{name}/eviction", api.EvictPod(clientset)).Methods("POST")
 	r.HandleFunc("/pods/{namespace}/{name}/logs", api.GetPodLogs(clientset)).Methods("GET")
+	r.HandleFunc("/pods/{namespace}/{name}/files", api.DownloadPodFiles(clientset, restConfig)).Methods("GET")
+	r.HandleFunc("/pods/{namespace}/{name}/files", api.UploadPodFiles(clientset, restConfig)).Methods("POST")
 }
@@ -0,0 +1,12 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"k8s.io/client-go/kubernetes"
+	"k8_gui/internal/api"
+)
+
+func RegisterScaleRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
+	r.HandleFunc("/{kind:deployments|statefulsets|replicasets}/{namespace}/{name}/scale", api.GetScale(clientset)).Methods("GET")
+	r.HandleFunc("/{kind:deployments|statefulsets|replicasets}/{namespace}/{name}/scale", api.UpdateScale(clientset)).Methods("PUT")
+}
@@ -0,0 +1,17 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"k8s.io/client-go/kubernetes"
+	"k8_gui/internal/api"
+	"k8_gui/internal/auth"
+)
+
+func RegisterSecretRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
+	r.HandleFunc("/secrets", api.ListSecrets(clientset)).Methods("GET")
+	r.HandleFunc("/secrets/{namespace}/{name}", api.GetSecret(clientset)).Methods("GET")
+	r.HandleFunc("/secrets", api.CreateSecret(clientset)).Methods("POST")
+	r.HandleFunc("/secrets/{namespace}/{name}", api.UpdateSecret(clientset)).Methods("PUT")
+	r.HandleFunc("/secrets/{namespace}/{name}", api.DeleteSecret(clientset)).Methods("DELETE")
+	r.Handle("/secrets/{namespace}/{name}/reveal", auth.RequireSecretReveal(api.RevealSecret(clientset))).Methods("POST")
+}
@@ -9,6 +9,8 @@ import (
 func RegisterServiceRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
 	r.HandleFunc("/services", api.ListServices(clientset)).Methods("GET")
 	r.HandleFunc("/services/{namespace}/{name}", api.GetService(clientset)).Methods("GET")
+	r.HandleFunc("/services/{namespace}/{name}/endpoints", api.GetServiceEndpoints(clientset)).Methods("GET")
 	r.HandleFunc("/services", api.CreateService(clientset)).Methods("POST")
+	r.HandleFunc("/services/{namespace}/{name}", api.UpdateService(clientset)).Methods("PUT")
 	r.HandleFunc("/services/{namespace}/{name}", api.DeleteService(clientset)).Methods("DELETE")
 }
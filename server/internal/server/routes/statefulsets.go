@@ -0,0 +1,15 @@
+package routes
+
+import (
+	"github.com/gorilla/mux"
+	"k8s.io/client-go/kubernetes"
+	"k8_gui/internal/api"
+)
+
+func RegisterStatefulSetRoutes(r *mux.Router, clientset *kubernetes.Clientset) {
+	r.HandleFunc("/statefulsets", api.ListStatefulSets(clientset)).Methods("GET")
+	r.HandleFunc("/statefulsets/{namespace}/{name}", api.GetStatefulSet(clientset)).Methods("GET")
+	r.HandleFunc("/statefulsets/{namespace}/{name}", api.DeleteStatefulSet(clientset)).Methods("DELETE")
+	r.HandleFunc("/statefulsets", api.CreateStatefulSet(clientset)).Methods("POST")
+	r.HandleFunc("/statefulsets/{namespace}/{name}/restart", api.RestartStatefulSet(clientset)).Methods("POST")
+}
@@ -5,6 +5,7 @@ const (
 	LogWarnInitK8sClient    = "Warning: Error initializing Kubernetes client: %v"
 	LogNoEnvFile            = "No .env file found"
 	LogLimitedServer        = "Starting server with limited functionality (auth endpoints will still work)"
+	LogFailedShutdownServer = "Failed to shut down server gracefully: %v"
 	LogK8sClientInitSuccess = "Kubernetes client initialized successfully"
 
 	LogFailedListPods       = "Failed to list pods: %v"
@@ -12,13 +13,30 @@ const (
 	LogFailedGetPod         = "Failed to get pod: %v"
 	LogFailedEncodePod      = "Failed to encode pod: %v"
 	LogFailedDeletePod      = "Failed to delete pod: %v"
+	LogFailedEvictPod       = "Failed to evict pod %s: %v"
 	LogFailedGetPodLogs     = "Failed to get pod logs: %v"
 	LogFailedWritePodLogs   = "Failed to write pod logs to response: %v"
 
-	LogFailedListNodes       = "Failed to list nodes: %v"
-	LogFailedEncodeNodesList = "Failed to encode nodes list: %v"
-	LogFailedGetNode         = "Failed to get node: %v"
-	LogFailedEncodeNode      = "Failed to encode node: %v"
+	LogFailedDownloadPodFiles    = "Failed to download %s from pod %s: %v (stderr: %s)"
+	LogFailedStreamPodFiles      = "Failed while streaming %s from pod %s: %v"
+	LogFailedUploadPodFiles      = "Failed to upload files to %s in pod %s: %v (stderr: %s)"
+	LogFailedEncodePodFileUpload = "Failed to encode pod file upload response: %v"
+
+	LogFailedListNodes             = "Failed to list nodes: %v"
+	LogFailedEncodeNodesList       = "Failed to encode nodes list: %v"
+	LogFailedGetNode               = "Failed to get node: %v"
+	LogFailedEncodeNode            = "Failed to encode node: %v"
+	LogFailedListNodePods          = "Failed to list pods for node allocation: %v"
+	LogFailedListPodsOnNode        = "Failed to list pods on node %s: %v"
+	LogFailedGetNodePodMetrics     = "Failed to get pod metrics for node %s: %v"
+	LogFailedEncodeNodePods        = "Failed to encode node pods: %v"
+	LogFailedCordonNode            = "Failed to update schedulability of node %s: %v"
+	LogFailedUpdateNode            = "Failed to update node %s: %v"
+	LogFailedEncodeUpdatedNode     = "Failed to encode updated node: %v"
+	LogDrainStarted                = "Started drain %s of node %s"
+	LogDrainFinished               = "Drain %s of node %s finished: %s %s"
+	LogFailedListDisruptionBudgets = "Failed to list pod disruption budgets in %s: %v"
+	LogFailedEncodeDrainJob        = "Failed to encode drain job: %v"
 
 	LogFailedListNamespaces         = "Failed to list namespaces: %v"
 	LogFailedEncodeNamespacesList   = "Failed to encode namespaces list: %v"
@@ -34,34 +52,135 @@ const (
 	LogFailedEncodeDeployment        = "Failed to encode deployment: %v"
 	LogFailedCreateDeployment        = "Failed to create deployment: %v"
 	LogFailedEncodeCreatedDeployment = "Failed to encode created deployment: %v"
-	LogFailedUpdateDeployment        = "Failed to update deployment: %v"
 	LogFailedEncodeUpdatedDeployment = "Failed to encode updated deployment: %v"
 	LogFailedDeleteDeployment        = "Failed to delete deployment: %v"
+	LogFailedPatchDeployment         = "Failed to patch deployment %s: %v"
+	LogFailedListReplicaSets         = "Failed to list replica sets for deployment %s: %v"
+	LogFailedDiffTemplate            = "Failed to diff pod template of replica set %s: %v"
+	LogFailedEncodeDeploymentHistory = "Failed to encode deployment history: %v"
+	LogFailedRollbackDeployment      = "Failed to roll back deployment %s: %v"
+	LogFailedEncodeRolloutStatus     = "Failed to encode rollout status: %v"
+	LogFailedWatchDeployment         = "Failed to watch deployment %s: %v"
+
+	LogFailedGetScale    = "Failed to get scale of %s %s: %v"
+	LogFailedUpdateScale = "Failed to update scale of %s %s: %v"
+	LogFailedEncodeScale = "Failed to encode scale: %v"
+
+	LogFailedListStatefulSets         = "Failed to list statefulsets: %v"
+	LogFailedEncodeStatefulSetsList   = "Failed to encode statefulsets list: %v"
+	LogFailedGetStatefulSet           = "Failed to get statefulset: %v"
+	LogFailedListStatefulSetPods      = "Failed to list pods of statefulset %s: %v"
+	LogFailedEncodeStatefulSet        = "Failed to encode statefulset: %v"
+	LogFailedCreateStatefulSet        = "Failed to create statefulset: %v"
+	LogFailedEncodeCreatedStatefulSet = "Failed to encode created statefulset: %v"
+	LogFailedDeleteStatefulSet        = "Failed to delete statefulset: %v"
+	LogFailedPatchStatefulSet         = "Failed to patch statefulset %s: %v"
+	LogFailedEncodeUpdatedStatefulSet = "Failed to encode updated statefulset: %v"
+
+	LogFailedListDaemonSets         = "Failed to list daemonsets: %v"
+	LogFailedEncodeDaemonSetsList   = "Failed to encode daemonsets list: %v"
+	LogFailedGetDaemonSet           = "Failed to get daemonset: %v"
+	LogFailedEncodeDaemonSet        = "Failed to encode daemonset: %v"
+	LogFailedDeleteDaemonSet        = "Failed to delete daemonset: %v"
+	LogFailedPatchDaemonSet         = "Failed to patch daemonset %s: %v"
+	LogFailedEncodeUpdatedDaemonSet = "Failed to encode updated daemonset: %v"
+	LogFailedListDaemonSetPods      = "Failed to list pods of daemonset %s: %v"
+	LogFailedEncodeDaemonSetNodes   = "Failed to encode daemonset nodes: %v"
+
+	LogFailedListJobs           = "Failed to list jobs: %v"
+	LogFailedEncodeJobsList     = "Failed to encode jobs list: %v"
+	LogFailedGetJob             = "Failed to get job: %v"
+	LogFailedEncodeJob          = "Failed to encode job: %v"
+	LogFailedDeleteJob          = "Failed to delete job: %v"
+	LogFailedListCronJobs       = "Failed to list cronjobs: %v"
+	LogFailedEncodeCronJobsList = "Failed to encode cronjobs list: %v"
+	LogFailedGetCronJob         = "Failed to get cronjob: %v"
+	LogFailedEncodeCronJob      = "Failed to encode cronjob: %v"
+	LogFailedDeleteCronJob      = "Failed to delete cronjob: %v"
+	LogFailedPatchCronJob       = "Failed to patch cronjob %s: %v"
+	LogFailedTriggerCronJob     = "Failed to create job from cronjob %s: %v"
+	LogFailedEncodeTriggeredJob = "Failed to encode triggered job: %v"
+
+	LogFailedListConfigMaps         = "Failed to list configmaps: %v"
+	LogFailedEncodeConfigMapsList   = "Failed to encode configmaps list: %v"
+	LogFailedGetConfigMap           = "Failed to get configmap: %v"
+	LogFailedEncodeConfigMap        = "Failed to encode configmap: %v"
+	LogFailedCreateConfigMap        = "Failed to create configmap: %v"
+	LogFailedEncodeCreatedConfigMap = "Failed to encode created configmap: %v"
+	LogFailedUpdateConfigMap        = "Failed to update configmap %s: %v"
+	LogFailedEncodeUpdatedConfigMap = "Failed to encode updated configmap: %v"
+	LogFailedDeleteConfigMap        = "Failed to delete configmap: %v"
+	LogFailedFindConsumers          = "Failed to find consumers of %s: %v"
+	LogFailedRestartConsumer        = "Failed to restart %s %s/%s: %v"
+
+	LogFailedListSecrets         = "Failed to list secrets: %v"
+	LogFailedEncodeSecretsList   = "Failed to encode secrets list: %v"
+	LogFailedGetSecret           = "Failed to get secret: %v"
+	LogFailedEncodeSecret        = "Failed to encode secret: %v"
+	LogFailedCreateSecret        = "Failed to create secret: %v"
+	LogFailedEncodeCreatedSecret = "Failed to encode created secret: %v"
+	LogFailedUpdateSecret        = "Failed to update secret %s: %v"
+	LogFailedEncodeUpdatedSecret = "Failed to encode updated secret: %v"
+	LogFailedDeleteSecret        = "Failed to delete secret: %v"
+	LogSecretRevealed            = "AUDIT: user %q revealed keys %v of secret %s/%s from %s"
+	LogFailedRecordSecretReveal  = "Failed to record reveal event for secret %s/%s: %v"
+	LogFailedEncodeSecretReveal  = "Failed to encode revealed secret: %v"
+
+	LogFailedListIngresses             = "Failed to list ingresses: %v"
+	LogFailedEncodeIngressesList       = "Failed to encode ingresses list: %v"
+	LogFailedGetIngress                = "Failed to get ingress: %v"
+	LogFailedEncodeIngress             = "Failed to encode ingress: %v"
+	LogFailedCreateIngress             = "Failed to create ingress: %v"
+	LogFailedEncodeCreatedIngress      = "Failed to encode created ingress: %v"
+	LogFailedDeleteIngress             = "Failed to delete ingress: %v"
+	LogFailedResolveIngressBackends    = "Failed to resolve backends of ingress %s: %v"
+	LogFailedEncodeIngressRoutes       = "Failed to encode ingress routes: %v"
+	LogFailedListIngressClasses        = "Failed to list ingress classes: %v"
+	LogFailedEncodeIngressClassesList  = "Failed to encode ingress classes list: %v"
+	LogFailedGetIngressClass           = "Failed to get ingress class: %v"
+	LogFailedEncodeIngressClass        = "Failed to encode ingress class: %v"
+	LogFailedCreateIngressClass        = "Failed to create ingress class: %v"
+	LogFailedEncodeCreatedIngressClass = "Failed to encode created ingress class: %v"
+	LogFailedDeleteIngressClass        = "Failed to delete ingress class: %v"
 
 	LogFailedListEvents                = "Failed to list events: %v"
 	LogFailedEncodeEventsList          = "Failed to encode events list: %v"
 	LogFailedListEventsNamespace       = "Failed to list events for namespace %s: %v"
 	LogFailedEncodeEventsListNamespace = "Failed to encode events list for namespace: %v"
 
-	LogFailedListServices         = "Failed to list services: %v"
-	LogFailedEncodeServicesList   = "Failed to encode services list: %v"
-	LogFailedGetService           = "Failed to get service: %v"
-	LogFailedEncodeService        = "Failed to encode service: %v"
-	LogFailedCreateService        = "Failed to create service: %v"
-	LogFailedEncodeCreatedService = "Failed to encode created service: %v"
-	LogFailedDeleteService        = "Failed to delete service: %v"
+	LogFailedListServices           = "Failed to list services: %v"
+	LogFailedEncodeServicesList     = "Failed to encode services list: %v"
+	LogFailedGetService             = "Failed to get service: %v"
+	LogFailedEncodeService          = "Failed to encode service: %v"
+	LogFailedCreateService          = "Failed to create service: %v"
+	LogFailedEncodeCreatedService   = "Failed to encode created service: %v"
+	LogFailedDeleteService          = "Failed to delete service: %v"
+	LogFailedUpdateService          = "Failed to update service %s: %v"
+	LogFailedEncodeUpdatedService   = "Failed to encode updated service: %v"
+	LogFailedListServicePods        = "Failed to list pods for service health: %v"
+	LogFailedListEndpointSlices     = "Failed to list endpoint slices for service %s: %v"
+	LogFailedEncodeServiceEndpoints = "Failed to encode service endpoints: %v"
 
 	LogFailedGetNodeMetrics                = "Failed to get node %s: %v"
 	LogFailedGetNodeMetricsAPI             = "Failed to get metrics for node %s: %v"
-	LogFailedEncodeNodeMetricsError        = "Failed to encode node metrics error response: %v"
 	LogFailedEncodeNodeMetrics             = "Failed to encode node metrics: %v"
 	LogFailedGetNodeMetricsList            = "Failed to get node metrics: %v"
 	LogFailedEncodeNodeMetricsList         = "Failed to encode node metrics list: %v"
+	LogFailedScrapeNodeMetrics             = "Failed to scrape node metrics for history: %v"
+	LogFailedScrapePodMetrics              = "Failed to scrape pod metrics for history: %v"
+	LogFailedScrapeOwners                  = "Failed to resolve pod owners for metrics history: %v"
+	LogMetricsHistoryStarted               = "Recording metrics history every %s, keeping %s (raw samples for %s, then %s averages)"
+	LogFailedEncodeMetricsHistory          = "Failed to encode metrics history: %v"
+	LogFailedQueryMetricsHistory           = "Failed to query metrics history of %s %s/%s: %v"
+	LogFailedInitPrometheus                = "Failed to configure Prometheus metrics provider, falling back to metrics-server: %v"
+	LogMetricsProvider                     = "Using %s as metrics provider"
 	LogFailedGetPodMetrics                 = "Failed to get pod metrics: %v"
 	LogFailedEncodePodMetricsList          = "Failed to encode pod metrics list: %v"
 	LogFailedGetPodMetricsNamespace        = "Failed to get pod metrics for namespace %s: %v"
 	LogFailedEncodePodMetricsListNamespace = "Failed to encode pod metrics list for namespace: %v"
 
+	LogFailedEncodeValidationErrors = "Failed to encode validation errors: %v"
+
 	LogFailedGetServerVersion     = "Failed to get server version: %v"
 	LogFailedEncodeClusterInfo    = "Failed to encode cluster info: %v"
 	LogFailedEncodeClusterHealth  = "Failed to encode cluster health: %v"
@@ -78,31 +197,130 @@ const (
 	MsgInvalidToken                = "Invalid token"
 	MsgMissingAuthorizationHeader  = "Missing Authorization header"
 	MsgInvalidOrExpiredToken       = "Invalid or expired token"
+	MsgValidationFailed            = "Request validation failed"
 
-	MsgFailedListServices  = "Failed to list services"
-	MsgServiceNotFound     = "Service not found"
-	MsgFailedCreateService = "Failed to create service"
-	MsgFailedDeleteService = "Failed to delete service"
+	MsgFailedListServices       = "Failed to list services"
+	MsgServiceNotFound          = "Service not found"
+	MsgFailedCreateService      = "Failed to create service"
+	MsgFailedDeleteService      = "Failed to delete service"
+	MsgServiceAlreadyExists     = "Service already exists"
+	MsgFailedUpdateService      = "Failed to update service"
+	MsgServiceModified          = "Service was modified concurrently, please retry"
+	MsgFailedListEndpointSlices = "Failed to list service endpoints"
 
 	MsgFailedListPods       = "Failed to list pods"
 	MsgPodNotFound          = "Pod not found"
 	MsgFailedDeletePod      = "Failed to delete pod"
-	MsgInvalidTailParameter = "Invalid 'tail' parameter"
-	MsgFailedGetPodLogs     = "Failed to get pod logs"
+	MsgFailedEvictPod       = "Failed to evict pod"
+	MsgEvictionBlockedByPDB = "Cannot evict pod: it would violate a PodDisruptionBudget"
+
+	MsgInvalidGracePeriod       = "Invalid 'gracePeriodSeconds': must be a non-negative integer"
+	MsgInvalidForceParameter    = "Invalid 'force' parameter: must be true or false"
+	MsgInvalidPropagationPolicy = "Invalid 'propagationPolicy': must be Orphan, Background or Foreground"
+	MsgInvalidTailParameter     = "Invalid 'tail' parameter"
+	MsgFailedGetPodLogs         = "Failed to get pod logs"
 
-	MsgFailedListNodes = "Failed to list nodes"
-	MsgNodeNotFound    = "Node not found"
+	MsgInvalidContainerPath   = "Invalid container path: must be absolute and must not contain '..'"
+	MsgInvalidArchiveFormat   = "Invalid 'format' parameter: must be 'tar' or 'zip'"
+	MsgNoFilesUploaded        = "No files uploaded: expected multipart field 'files'"
+	MsgContainerNotFound      = "Container not found in pod"
+	MsgPodNotRunning          = "Pod is not running"
+	MsgPodFilesTooLarge       = "File size limit exceeded"
+	MsgFailedDownloadPodFiles = "Failed to download files from pod"
+	MsgPodFileNotFound        = "File or directory not found in container"
+	MsgFailedUploadPodFiles   = "Failed to upload files to pod"
+
+	MsgFailedListNodes      = "Failed to list nodes"
+	MsgNodeNotFound         = "Node not found"
+	MsgFailedCordonNode     = "Failed to update node schedulability"
+	MsgFailedUpdateNode     = "Failed to update node"
+	MsgNodeModified         = "Node was modified concurrently, please retry"
+	MsgFailedListPodsOnNode = "Failed to list pods on node"
+	MsgDrainInProgress      = "A drain of this node is already running"
+	MsgDrainNotFound        = "No drain found for this node"
 
 	MsgFailedListNamespaces  = "Failed to list namespaces"
 	MsgNamespaceNotFound     = "Namespace not found"
 	MsgFailedCreateNamespace = "Failed to create namespace"
 	MsgFailedDeleteNamespace = "Failed to delete namespace"
 
-	MsgFailedListDeployments  = "Failed to list deployments"
-	MsgDeploymentNotFound     = "Deployment not found"
-	MsgFailedCreateDeployment = "Failed to create deployment"
-	MsgFailedUpdateDeployment = "Failed to update deployment"
-	MsgFailedDeleteDeployment = "Failed to delete deployment"
+	MsgFailedListDeployments   = "Failed to list deployments"
+	MsgDeploymentNotFound      = "Deployment not found"
+	MsgFailedCreateDeployment  = "Failed to create deployment"
+	MsgDeploymentAlreadyExists = "Deployment already exists"
+	MsgFailedUpdateDeployment  = "Failed to update deployment"
+	MsgFailedDeleteDeployment  = "Failed to delete deployment"
+	MsgDeploymentPaused        = "Cannot restart a paused deployment: resume it first"
+
+	MsgFailedGetDeploymentHistory = "Failed to get deployment history"
+	MsgFailedRollbackDeployment   = "Failed to roll back deployment"
+	MsgDeploymentPausedRollback   = "Cannot roll back a paused deployment: resume it first"
+	MsgRevisionNotFound           = "Revision not found"
+	MsgInvalidRevision            = "Invalid revision: must be a non-negative integer"
+	MsgDeploymentModified         = "Deployment was modified concurrently, please retry"
+	MsgFailedWatchDeployment      = "Failed to watch deployment"
+	MsgInvalidTimeoutParameter    = "Invalid 'timeout' parameter: must be a positive duration of at most 30m, such as 5m"
+	MsgStreamingUnsupported       = "Streaming not supported"
+
+	MsgUnsupportedScaleKind = "Unsupported kind: must be deployments, statefulsets or replicasets"
+	MsgWorkloadNotFound     = "Workload not found"
+	MsgWorkloadModified     = "Workload was modified concurrently, please retry"
+	MsgFailedGetScale       = "Failed to get scale"
+	MsgFailedUpdateScale    = "Failed to update scale"
+
+	MsgFailedListStatefulSets   = "Failed to list statefulsets"
+	MsgStatefulSetNotFound      = "StatefulSet not found"
+	MsgFailedCreateStatefulSet  = "Failed to create statefulset"
+	MsgStatefulSetAlreadyExists = "StatefulSet already exists"
+	MsgFailedUpdateStatefulSet  = "Failed to update statefulset"
+	MsgFailedDeleteStatefulSet  = "Failed to delete statefulset"
+
+	MsgFailedListDaemonSets    = "Failed to list daemonsets"
+	MsgDaemonSetNotFound       = "DaemonSet not found"
+	MsgFailedUpdateDaemonSet   = "Failed to update daemonset"
+	MsgFailedDeleteDaemonSet   = "Failed to delete daemonset"
+	MsgFailedGetDaemonSetNodes = "Failed to get daemonset nodes"
+
+	MsgFailedListJobs       = "Failed to list jobs"
+	MsgJobNotFound          = "Job not found"
+	MsgFailedDeleteJob      = "Failed to delete job"
+	MsgFailedListCronJobs   = "Failed to list cronjobs"
+	MsgCronJobNotFound      = "CronJob not found"
+	MsgFailedUpdateCronJob  = "Failed to update cronjob"
+	MsgFailedDeleteCronJob  = "Failed to delete cronjob"
+	MsgFailedTriggerCronJob = "Failed to create job from cronjob"
+	MsgJobAlreadyExists     = "Job already exists"
+
+	MsgFailedListConfigMaps   = "Failed to list configmaps"
+	MsgConfigMapNotFound      = "ConfigMap not found"
+	MsgFailedCreateConfigMap  = "Failed to create configmap"
+	MsgConfigMapAlreadyExists = "ConfigMap already exists"
+	MsgFailedUpdateConfigMap  = "Failed to update configmap"
+	MsgConfigMapModified      = "ConfigMap was modified concurrently, please retry"
+	MsgFailedDeleteConfigMap  = "Failed to delete configmap"
+	MsgFailedFindConsumers    = "Failed to find consumers"
+	MsgInvalidConsumersParam  = "Invalid 'consumers' parameter: must be true or false"
+
+	MsgFailedListSecrets     = "Failed to list secrets"
+	MsgSecretNotFound        = "Secret not found"
+	MsgFailedCreateSecret    = "Failed to create secret"
+	MsgSecretAlreadyExists   = "Secret already exists"
+	MsgFailedUpdateSecret    = "Failed to update secret"
+	MsgSecretModified        = "Secret was modified concurrently, please retry"
+	MsgFailedDeleteSecret    = "Failed to delete secret"
+	MsgSecretRevealForbidden = "Revealing secret values requires elevated permission"
+
+	MsgFailedListIngresses       = "Failed to list ingresses"
+	MsgIngressNotFound           = "Ingress not found"
+	MsgFailedCreateIngress       = "Failed to create ingress"
+	MsgIngressAlreadyExists      = "Ingress already exists"
+	MsgFailedDeleteIngress       = "Failed to delete ingress"
+	MsgFailedGetIngressRoutes    = "Failed to get ingress routes"
+	MsgFailedListIngressClasses  = "Failed to list ingress classes"
+	MsgIngressClassNotFound      = "IngressClass not found"
+	MsgFailedCreateIngressClass  = "Failed to create ingress class"
+	MsgIngressClassAlreadyExists = "IngressClass already exists"
+	MsgFailedDeleteIngressClass  = "Failed to delete ingress class"
 
 	MsgFailedListEvents = "Failed to list events"
 
@@ -110,8 +328,11 @@ const (
 	MsgFailedGetClusterHealth  = "Failed to get cluster health"
 	MsgFailedGetClusterVersion = "Failed to get cluster version"
 
-	MsgFailedGetNodeMetrics = "Failed to get node metrics"
-	MsgFailedGetPodMetrics  = "Failed to get pod metrics"
+	MsgFailedGetNodeMetrics      = "Failed to get node metrics"
+	MsgInvalidHistoryRange       = "Invalid history range"
+	MsgFailedGetMetricsHistory   = "Failed to get metrics history"
+	MsgMetricsHistoryUnsupported = "Metrics history is not supported by the configured provider"
+	MsgFailedGetPodMetrics       = "Failed to get pod metrics"
 
 	MsgServerRunningLimited = `{"status": "ok", "message": "Server running (Kubernetes not available)"}`
 )